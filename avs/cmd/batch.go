@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// MaxBatchSize is the largest number of sub-tasks accepted in one batch task
+const MaxBatchSize = 16
+
+// Error codes reported for failed batch sub-tasks. The full error is only logged:
+// its text can differ between operators and must stay out of the signed result.
+const (
+	BatchErrorInvalidParameters = "invalid_parameters"
+	BatchErrorSourceUnavailable = "source_unavailable"
+	BatchErrorUnsupportedType   = "unsupported_type"
+	BatchErrorTaskFailed        = "task_failed"
+)
+
+// errUnsupportedTaskType marks tasks of a type the handler cannot run
+var errUnsupportedTaskType = errors.New("unsupported task type")
+
+// BatchSubTaskResult is the outcome of one sub-task of a batch. Result holds the
+// sub-task's own result document; ErrorCode is set instead when the sub-task failed.
+type BatchSubTaskResult struct {
+	Index     int             `json:"index"`
+	Type      TaskType        `json:"type"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	ErrorCode string          `json:"error_code,omitempty"`
+}
+
+// BatchResult holds the results of a batch task's sub-tasks in request order
+type BatchResult struct {
+	Results   []BatchSubTaskResult `json:"results"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Timestamp int64                `json:"timestamp,omitempty"`
+}
+
+// batchSubTask returns the i-th sub-task of a batch. Sub-tasks without their own
+// timestamp inherit the batch's so their results stay deterministic.
+func batchSubTask(batch *TaskPayload, i int) *TaskPayload {
+	subTask := batch.SubTasks[i]
+	if subTask.Timestamp == 0 {
+		subTask.Timestamp = batch.Timestamp
+	}
+	return &subTask
+}
+
+// batchErrorCode classifies a sub-task error into one of the BatchError codes
+func batchErrorCode(err error) string {
+	var fieldErr *FieldError
+	switch {
+	case errors.Is(err, errSourceUnavailable):
+		return BatchErrorSourceUnavailable
+	case errors.Is(err, errUnsupportedTaskType):
+		return BatchErrorUnsupportedType
+	case errors.As(err, &fieldErr):
+		return BatchErrorInvalidParameters
+	default:
+		return BatchErrorTaskFailed
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+// testBatchSubTasks returns a monitor, risk, and adjustment bundle with a de-peg
+// check in the middle
+func testBatchSubTasks() []TaskPayload {
+	return []TaskPayload{
+		{
+			Type:       TaskTypeYieldMonitoring,
+			LSTData:    testLSTData(),
+			Parameters: map[string]interface{}{"pool_address": "0xpool"},
+		},
+		{
+			Type:       TaskTypeDepegCheck,
+			Parameters: map[string]interface{}{"token_address": "0xlst"},
+		},
+		{
+			Type:       TaskTypeRiskAssessment,
+			LSTData:    testLSTData(),
+			Parameters: map[string]interface{}{},
+		},
+		{
+			Type:       TaskTypePositionAdjustment,
+			Position:   testPosition(),
+			Parameters: map[string]interface{}{},
+		},
+	}
+}
+
+func Test_BatchTask(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	failingPegs := PegDataSourceFunc(func(ctx context.Context, tokenAddress string) (*PegQuote, error) {
+		return nil, errors.New("price source unavailable")
+	})
+
+	testCases := []struct {
+		name            string
+		pegs            PegDataSource
+		continueOnError bool
+		wantErr         bool
+		wantSucceeded   int
+		wantFailed      int
+	}{
+		{name: "All Succeed", pegs: atPegSource(), wantSucceeded: 4},
+		{name: "All Succeed Collecting Errors", pegs: atPegSource(), continueOnError: true, wantSucceeded: 4},
+		{name: "Failing Middle Sub-task Short-circuits", pegs: failingPegs, wantErr: true},
+		{name: "Failing Middle Sub-task Is Collected", pegs: failingPegs, continueOnError: true, wantSucceeded: 3, wantFailed: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), DataSources{
+				Validators: healthyValidatorSource(),
+				Pegs:       tc.pegs,
+			})
+
+			payloadBytes, err := json.Marshal(TaskPayload{
+				Type:       TaskTypeBatch,
+				Parameters: map[string]interface{}{"continue_on_error": tc.continueOnError},
+				SubTasks:   testBatchSubTasks(),
+				Timestamp:  1700000000,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal payload: %v", err)
+			}
+
+			taskRequest := &performerV1.TaskRequest{
+				TaskId:  []byte("batch-task"),
+				Payload: payloadBytes,
+			}
+
+			if err := performer.ValidateTask(taskRequest); err != nil {
+				t.Fatalf("ValidateTask failed: %v", err)
+			}
+
+			resp, err := performer.HandleTask(taskRequest)
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "sub-task 1 (depeg_check)") {
+					t.Fatalf("Expected the batch to fail on sub-task 1, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("HandleTask failed: %v", err)
+			}
+
+			var result BatchResult
+			if err := json.Unmarshal(resp.Result, &result); err != nil {
+				t.Fatalf("Failed to decode result: %v", err)
+			}
+
+			if result.Succeeded != tc.wantSucceeded || result.Failed != tc.wantFailed {
+				t.Errorf("Expected %d succeeded and %d failed, got %d and %d", tc.wantSucceeded, tc.wantFailed, result.Succeeded, result.Failed)
+			}
+			if len(result.Results) != len(testBatchSubTasks()) {
+				t.Fatalf("Expected a result per sub-task, got %d", len(result.Results))
+			}
+
+			for i, subTask := range testBatchSubTasks() {
+				subResult := result.Results[i]
+				if subResult.Index != i || subResult.Type != subTask.Type {
+					t.Errorf("Expected result %d for %s, got %d for %s", i, subTask.Type, subResult.Index, subResult.Type)
+				}
+				failed := subResult.ErrorCode != ""
+				if failed != (tc.wantFailed > 0 && i == 1) {
+					t.Errorf("Unexpected outcome for sub-task %d: %+v", i, subResult)
+				}
+				if failed && subResult.ErrorCode != BatchErrorSourceUnavailable {
+					t.Errorf("Expected error code %s for sub-task %d, got %s", BatchErrorSourceUnavailable, i, subResult.ErrorCode)
+				}
+				if !failed && len(subResult.Result) == 0 {
+					t.Errorf("Expected a result for sub-task %d", i)
+				}
+			}
+
+			// Sub-tasks inherit the batch timestamp
+			var monitoring YieldMonitoringResult
+			if err := json.Unmarshal(result.Results[0].Result, &monitoring); err != nil {
+				t.Fatalf("Failed to decode monitoring sub-task result: %v", err)
+			}
+			if monitoring.Timestamp != 1700000000 {
+				t.Errorf("Expected sub-task timestamp 1700000000, got %d", monitoring.Timestamp)
+			}
+		})
+	}
+}
+
+func Test_BatchTaskValidation(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+	invalidMiddle := testBatchSubTasks()
+	invalidMiddle[1].Parameters = map[string]interface{}{}
+
+	testCases := []struct {
+		name     string
+		subTasks []TaskPayload
+	}{
+		{name: "Empty Batch", subTasks: nil},
+		{name: "Nested Batch", subTasks: []TaskPayload{{Type: TaskTypeBatch, SubTasks: testBatchSubTasks()}}},
+		{name: "Invalid Sub-task", subTasks: invalidMiddle},
+		{name: "Too Many Sub-tasks", subTasks: make([]TaskPayload, MaxBatchSize+1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payloadBytes, err := json.Marshal(TaskPayload{
+				Type:       TaskTypeBatch,
+				Parameters: map[string]interface{}{},
+				SubTasks:   tc.subTasks,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal payload: %v", err)
+			}
+
+			taskRequest := &performerV1.TaskRequest{
+				TaskId:  []byte("batch-validation"),
+				Payload: payloadBytes,
+			}
+			if err := performer.ValidateTask(taskRequest); err == nil {
+				t.Errorf("Expected %s to be rejected", tc.name)
+			}
+		})
+	}
+}
+
+func Test_BatchWithFailedSubTaskIsNotCached(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	// The price source is down for the first attempt and recovers for the retry
+	sourceDown := true
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), DataSources{
+		Validators: healthyValidatorSource(),
+		Pegs: PegDataSourceFunc(func(ctx context.Context, tokenAddress string) (*PegQuote, error) {
+			if sourceDown {
+				return nil, errors.New("price source unavailable")
+			}
+			return &PegQuote{MarketPrice: 1.1, RedemptionRate: 1.1}, nil
+		}),
+	})
+
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type:       TaskTypeBatch,
+		Parameters: map[string]interface{}{"continue_on_error": true},
+		SubTasks:   testBatchSubTasks(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+
+	taskRequest := &performerV1.TaskRequest{
+		TaskId:  []byte("batch-retry"),
+		Payload: payloadBytes,
+	}
+
+	handle := func() BatchResult {
+		resp, err := performer.HandleTask(taskRequest)
+		if err != nil {
+			t.Fatalf("HandleTask failed: %v", err)
+		}
+		var result BatchResult
+		if err := json.Unmarshal(resp.Result, &result); err != nil {
+			t.Fatalf("Failed to decode result: %v", err)
+		}
+		return result
+	}
+
+	if first := handle(); first.Failed != 1 {
+		t.Fatalf("Expected one failed sub-task, got %d", first.Failed)
+	}
+	if performer.results.Len() != 0 {
+		t.Errorf("Expected a partially failed batch not to be cached")
+	}
+
+	sourceDown = false
+	if retry := handle(); retry.Failed != 0 || retry.Succeeded != len(testBatchSubTasks()) {
+		t.Errorf("Expected the retry to recompute every sub-task, got %d succeeded and %d failed", retry.Succeeded, retry.Failed)
+	}
+	if performer.taskCount != 2 {
+		t.Errorf("Expected two processed tasks, got %d", performer.taskCount)
+	}
+
+	// The fully successful result is cached for further retries
+	handle()
+	if performer.taskCount != 2 {
+		t.Errorf("Expected the successful batch to be served from cache, got %d processed tasks", performer.taskCount)
+	}
+}
+
+func Test_BatchErrorCode(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "Source Unavailable", err: fmt.Errorf("failed to load peg prices: %w: %w", errSourceUnavailable, errors.New("dial tcp 10.0.0.1:443: i/o timeout")), want: BatchErrorSourceUnavailable},
+		{name: "Unsupported Type", err: fmt.Errorf("%w: nested batch tasks are not supported", errUnsupportedTaskType), want: BatchErrorUnsupportedType},
+		{name: "Invalid Parameters", err: fmt.Errorf("invalid depeg check parameters: %w", &FieldError{Field: "parameters.token_address", Message: "required field is missing"}), want: BatchErrorInvalidParameters},
+		{name: "Other Failure", err: errors.New("redemption rate for 0xlst must be positive, got 0"), want: BatchErrorTaskFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := batchErrorCode(tc.err); got != tc.want {
+				t.Errorf("Expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_BatchResultOmitsErrorText(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	// Two operators see different network errors for the same unavailable source
+	var results [][]byte
+	for _, address := range []string{"10.0.0.1:443", "10.0.0.2:443"} {
+		address := address
+		performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), DataSources{
+			Validators: healthyValidatorSource(),
+			Pegs: PegDataSourceFunc(func(ctx context.Context, tokenAddress string) (*PegQuote, error) {
+				return nil, fmt.Errorf("dial tcp %s: i/o timeout", address)
+			}),
+		})
+
+		payloadBytes, err := json.Marshal(TaskPayload{
+			Type:       TaskTypeBatch,
+			Parameters: map[string]interface{}{"continue_on_error": true},
+			SubTasks:   testBatchSubTasks(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal payload: %v", err)
+		}
+
+		resp, err := performer.HandleTask(&performerV1.TaskRequest{TaskId: []byte("batch-error-text"), Payload: payloadBytes})
+		if err != nil {
+			t.Fatalf("HandleTask failed: %v", err)
+		}
+		results = append(results, resp.Result)
+	}
+
+	if string(results[0]) != string(results[1]) {
+		t.Errorf("Expected identical results\nfirst:  %s\nsecond: %s", results[0], results[1])
+	}
+	if strings.Contains(string(results[0]), "dial tcp") {
+		t.Errorf("Expected the error text to be kept out of the result: %s", results[0])
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// Default result cache sizing used by NewYieldSyncPerformer
+const (
+	DefaultResultCacheSize = 1024
+	DefaultResultCacheTTL  = 10 * time.Minute
+)
+
+// resultCacheEntry is a cached task result along with the payload it was computed from
+type resultCacheEntry struct {
+	taskId      string
+	payloadHash [32]byte
+	result      []byte
+	storedAt    time.Time
+}
+
+// ResultCache is a bounded LRU cache of task results keyed by TaskId. An entry is
+// only served when the retried task carries the same payload and is within the TTL,
+// which makes Executor retries of the same task return identical bytes.
+type ResultCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+func NewResultCache(capacity int, ttl time.Duration) *ResultCache {
+	return &ResultCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get returns the cached result for the task if the payload matches and the entry
+// has not expired.
+func (c *ResultCache) Get(taskId []byte, payload []byte) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[string(taskId)]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*resultCacheEntry)
+	if c.now().Sub(entry.storedAt) > c.ttl {
+		c.removeElement(elem)
+		return nil, false
+	}
+	if entry.payloadHash != sha256.Sum256(payload) {
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return append([]byte(nil), entry.result...), true
+}
+
+// Put stores the result for the task, replacing any previous entry for the same
+// TaskId and evicting the least recently used entry when full.
+func (c *ResultCache) Put(taskId []byte, payload []byte, result []byte) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[string(taskId)]; ok {
+		c.removeElement(elem)
+	}
+
+	entry := &resultCacheEntry{
+		taskId:      string(taskId),
+		payloadHash: sha256.Sum256(payload),
+		result:      append([]byte(nil), result...),
+		storedAt:    c.now(),
+	}
+	c.entries[entry.taskId] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Len returns the number of cached results
+func (c *ResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *ResultCache) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*resultCacheEntry).taskId)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+func Test_HandleTaskReturnsCachedResultOnRetry(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type:       TaskTypeLSTValidation,
+		Parameters: map[string]interface{}{"token_address": "0xlst"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+
+	taskRequest := &performerV1.TaskRequest{
+		TaskId:  []byte("retry-task"),
+		Payload: payloadBytes,
+	}
+
+	first, err := performer.HandleTask(taskRequest)
+	if err != nil {
+		t.Fatalf("HandleTask failed: %v", err)
+	}
+
+	// A recomputation would increment taskCount, so an unchanged count proves a cache hit
+	second, err := performer.HandleTask(taskRequest)
+	if err != nil {
+		t.Fatalf("HandleTask retry failed: %v", err)
+	}
+
+	if !bytes.Equal(first.Result, second.Result) {
+		t.Errorf("Expected retried task to return cached result\nfirst:  %s\nsecond: %s", first.Result, second.Result)
+	}
+	if performer.taskCount != 1 {
+		t.Errorf("Expected one processed task, got %d", performer.taskCount)
+	}
+
+	// A different payload under the same TaskId bypasses the cache
+	changedBytes, err := json.Marshal(TaskPayload{
+		Type:       TaskTypeLSTValidation,
+		Parameters: map[string]interface{}{"token_address": "0xother"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+
+	third, err := performer.HandleTask(&performerV1.TaskRequest{
+		TaskId:  []byte("retry-task"),
+		Payload: changedBytes,
+	})
+	if err != nil {
+		t.Fatalf("HandleTask with changed payload failed: %v", err)
+	}
+	if bytes.Equal(first.Result, third.Result) {
+		t.Errorf("Expected changed payload to bypass the cache")
+	}
+	if performer.taskCount != 2 {
+		t.Errorf("Expected two processed tasks, got %d", performer.taskCount)
+	}
+}
+
+func Test_ResultCacheExpiryAndEviction(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := NewResultCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Put([]byte("a"), []byte("payload"), []byte("result-a"))
+	cache.Put([]byte("b"), []byte("payload"), []byte("result-b"))
+
+	// Touch "a" so "b" becomes least recently used
+	if _, ok := cache.Get([]byte("a"), []byte("payload")); !ok {
+		t.Fatalf("Expected cache hit for a")
+	}
+	cache.Put([]byte("c"), []byte("payload"), []byte("result-c"))
+
+	if _, ok := cache.Get([]byte("b"), []byte("payload")); ok {
+		t.Errorf("Expected b to be evicted")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 cached entries, got %d", cache.Len())
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.Get([]byte("a"), []byte("payload")); ok {
+		t.Errorf("Expected a to expire after the TTL")
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/performer/server"
+)
+
+// Environment variables read by LoadPerformerConfig
+const (
+	EnvPerformerPort    = "PERFORMER_PORT"
+	EnvPerformerTimeout = "PERFORMER_TIMEOUT"
+	EnvValidatorSources = "PERFORMER_VALIDATOR_SOURCES"
+	EnvPegSources       = "PERFORMER_PEG_SOURCES"
+)
+
+// Environment variables overriding the handler parameter defaults
+const (
+	EnvDefaultYieldThreshold     = "PERFORMER_DEFAULT_YIELD_THRESHOLD"
+	EnvDefaultBaselineWindow     = "PERFORMER_DEFAULT_BASELINE_WINDOW"
+	EnvDefaultTargetYield        = "PERFORMER_DEFAULT_TARGET_YIELD"
+	EnvDefaultMaxSlippage        = "PERFORMER_DEFAULT_MAX_SLIPPAGE"
+	EnvDefaultRebalanceThreshold = "PERFORMER_DEFAULT_REBALANCE_THRESHOLD"
+	EnvDefaultBenefitHorizonDays = "PERFORMER_DEFAULT_BENEFIT_HORIZON_DAYS"
+	EnvDefaultDepegThresholdBps  = "PERFORMER_DEFAULT_DEPEG_THRESHOLD_BPS"
+	EnvDefaultMinValidatorCount  = "PERFORMER_DEFAULT_MIN_VALIDATOR_COUNT"
+	EnvDefaultMinHealthScore     = "PERFORMER_DEFAULT_MIN_HEALTH_SCORE"
+)
+
+// Default performer server settings
+const (
+	DefaultPerformerPort    = 8080
+	DefaultPerformerTimeout = 10 * time.Second // Longer timeout for complex calculations
+)
+
+// PerformerConfig holds the runtime settings of the YieldSync Performer.
+// ValidatorSources and PegSources map LST token addresses to the endpoints
+// serving their validator set data and market/redemption prices. Defaults
+// holds the parameter values handlers use when a task omits them.
+type PerformerConfig struct {
+	Port             int
+	Timeout          time.Duration
+	ValidatorSources map[string]string
+	PegSources       map[string]string
+	Defaults         HandlerDefaults
+}
+
+// DefaultPerformerConfig returns the configuration used when no overrides are set
+func DefaultPerformerConfig() *PerformerConfig {
+	return &PerformerConfig{
+		Port:     DefaultPerformerPort,
+		Timeout:  DefaultPerformerTimeout,
+		Defaults: DefaultHandlerDefaults(),
+	}
+}
+
+// LoadPerformerConfig builds the configuration from defaults overridden by the
+// PERFORMER_* environment variables, and validates the result.
+func LoadPerformerConfig() (*PerformerConfig, error) {
+	cfg := DefaultPerformerConfig()
+
+	if value, ok := os.LookupEnv(EnvPerformerPort); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvPerformerPort, value, err)
+		}
+		cfg.Port = port
+	}
+
+	if value, ok := os.LookupEnv(EnvPerformerTimeout); ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvPerformerTimeout, value, err)
+		}
+		cfg.Timeout = timeout
+	}
+
+	if value, ok := os.LookupEnv(EnvValidatorSources); ok {
+		sources, err := parseTokenEndpoints(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvValidatorSources, err)
+		}
+		cfg.ValidatorSources = sources
+	}
+
+	if value, ok := os.LookupEnv(EnvPegSources); ok {
+		sources, err := parseTokenEndpoints(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvPegSources, err)
+		}
+		cfg.PegSources = sources
+	}
+
+	if value, ok := os.LookupEnv(EnvDefaultBaselineWindow); ok {
+		window, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvDefaultBaselineWindow, value, err)
+		}
+		cfg.Defaults.BaselineWindow = window
+	}
+
+	if value, ok := os.LookupEnv(EnvDefaultMinValidatorCount); ok {
+		count, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvDefaultMinValidatorCount, value, err)
+		}
+		cfg.Defaults.MinValidatorCount = count
+	}
+
+	floatDefaults := []struct {
+		env   string
+		value *float64
+	}{
+		{EnvDefaultYieldThreshold, &cfg.Defaults.YieldThreshold},
+		{EnvDefaultTargetYield, &cfg.Defaults.TargetYield},
+		{EnvDefaultMaxSlippage, &cfg.Defaults.MaxSlippage},
+		{EnvDefaultRebalanceThreshold, &cfg.Defaults.RebalanceThreshold},
+		{EnvDefaultBenefitHorizonDays, &cfg.Defaults.BenefitHorizonDays},
+		{EnvDefaultDepegThresholdBps, &cfg.Defaults.DepegThresholdBps},
+		{EnvDefaultMinHealthScore, &cfg.Defaults.MinHealthScore},
+	}
+	for _, d := range floatDefaults {
+		value, ok := os.LookupEnv(d.env)
+		if !ok {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", d.env, value, err)
+		}
+		*d.value = parsed
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that the configuration values are usable
+func (c *PerformerConfig) Validate() error {
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("performer port must be between 1 and 65535, got %d", c.Port)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("performer timeout must be positive, got %s", c.Timeout)
+	}
+
+	if err := c.Defaults.Validate(); err != nil {
+		return fmt.Errorf("invalid handler defaults: %w", err)
+	}
+	return nil
+}
+
+// parseTokenEndpoints parses a comma-separated list of token=url pairs
+func parseTokenEndpoints(value string) (map[string]string, error) {
+	sources := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		token, endpoint, ok := strings.Cut(entry, "=")
+		if !ok || token == "" {
+			return nil, fmt.Errorf("entry %q must be of the form token=url", entry)
+		}
+
+		parsed, err := url.Parse(endpoint)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("entry %q has an invalid URL", entry)
+		}
+		sources[token] = endpoint
+	}
+	return sources, nil
+}
+
+// ServerConfig returns the Ponos server configuration for this performer
+func (c *PerformerConfig) ServerConfig() *server.PonosPerformerConfig {
+	return &server.PonosPerformerConfig{
+		Port:    c.Port,
+		Timeout: c.Timeout,
+	}
+}
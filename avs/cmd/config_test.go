@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+func Test_LoadPerformerConfigDefaults(t *testing.T) {
+	cfg, err := LoadPerformerConfig()
+	if err != nil {
+		t.Fatalf("Failed to load default config: %v", err)
+	}
+
+	serverConfig := cfg.ServerConfig()
+	if serverConfig.Port != DefaultPerformerPort {
+		t.Errorf("Expected default port %d, got %d", DefaultPerformerPort, serverConfig.Port)
+	}
+	if serverConfig.Timeout != DefaultPerformerTimeout {
+		t.Errorf("Expected default timeout %s, got %s", DefaultPerformerTimeout, serverConfig.Timeout)
+	}
+	if cfg.Defaults != DefaultHandlerDefaults() {
+		t.Errorf("Expected built-in handler defaults, got %+v", cfg.Defaults)
+	}
+}
+
+func Test_LoadPerformerConfigFromEnvironment(t *testing.T) {
+	t.Setenv(EnvPerformerPort, "9091")
+	t.Setenv(EnvPerformerTimeout, "45s")
+
+	cfg, err := LoadPerformerConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	serverConfig := cfg.ServerConfig()
+	if serverConfig.Port != 9091 {
+		t.Errorf("Expected port 9091, got %d", serverConfig.Port)
+	}
+	if serverConfig.Timeout != 45*time.Second {
+		t.Errorf("Expected timeout 45s, got %s", serverConfig.Timeout)
+	}
+}
+
+func Test_LoadPerformerConfigRejectsInvalidValues(t *testing.T) {
+	testCases := []struct {
+		name    string
+		port    string
+		timeout string
+	}{
+		{name: "Non-numeric Port", port: "http", timeout: "10s"},
+		{name: "Zero Port", port: "0", timeout: "10s"},
+		{name: "Port Out Of Range", port: "70000", timeout: "10s"},
+		{name: "Unparseable Timeout", port: "8080", timeout: "ten seconds"},
+		{name: "Negative Timeout", port: "8080", timeout: "-5s"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(EnvPerformerPort, tc.port)
+			t.Setenv(EnvPerformerTimeout, tc.timeout)
+
+			if _, err := LoadPerformerConfig(); err == nil {
+				t.Errorf("Expected config error for port=%q timeout=%q", tc.port, tc.timeout)
+			}
+		})
+	}
+}
+
+func Test_LoadPerformerConfigValidatorSources(t *testing.T) {
+	t.Setenv(EnvValidatorSources, "0xabc=https://lido.example/validators, 0xdef=http://localhost:9000/stats")
+
+	cfg, err := LoadPerformerConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if len(cfg.ValidatorSources) != 2 || cfg.ValidatorSources["0xdef"] != "http://localhost:9000/stats" {
+		t.Errorf("Unexpected validator sources: %v", cfg.ValidatorSources)
+	}
+
+	t.Setenv(EnvValidatorSources, "0xabc=not-a-url")
+	if _, err := LoadPerformerConfig(); err == nil {
+		t.Errorf("Expected an invalid validator source URL to be rejected")
+	}
+}
+
+func Test_LoadPerformerConfigHandlerDefaults(t *testing.T) {
+	t.Setenv(EnvDefaultYieldThreshold, "0.03")
+	t.Setenv(EnvDefaultBaselineWindow, "14")
+	t.Setenv(EnvDefaultMaxSlippage, "0.001")
+	t.Setenv(EnvDefaultDepegThresholdBps, "50")
+	t.Setenv(EnvDefaultMinValidatorCount, "500")
+	t.Setenv(EnvDefaultMinHealthScore, "90")
+
+	cfg, err := LoadPerformerConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	expected := DefaultHandlerDefaults()
+	expected.YieldThreshold = 0.03
+	expected.BaselineWindow = 14
+	expected.MaxSlippage = 0.001
+	expected.DepegThresholdBps = 50
+	expected.MinValidatorCount = 500
+	expected.MinHealthScore = 90
+	if cfg.Defaults != expected {
+		t.Errorf("Expected handler defaults %+v, got %+v", expected, cfg.Defaults)
+	}
+
+	testCases := []struct {
+		name  string
+		env   string
+		value string
+	}{
+		{name: "Unparseable Threshold", env: EnvDefaultYieldThreshold, value: "one percent"},
+		{name: "Negative Threshold", env: EnvDefaultYieldThreshold, value: "-0.01"},
+		{name: "Fractional Window", env: EnvDefaultBaselineWindow, value: "2.5"},
+		{name: "Slippage Of 100%", env: EnvDefaultMaxSlippage, value: "1"},
+		{name: "Zero Rebalance Threshold", env: EnvDefaultRebalanceThreshold, value: "0"},
+		{name: "Zero Benefit Horizon", env: EnvDefaultBenefitHorizonDays, value: "0"},
+		{name: "Negative Validator Count", env: EnvDefaultMinValidatorCount, value: "-1"},
+		{name: "Health Score Over Range", env: EnvDefaultMinHealthScore, value: "120"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(tc.env, tc.value)
+			if _, err := LoadPerformerConfig(); err == nil {
+				t.Errorf("Expected config error for %s=%q", tc.env, tc.value)
+			}
+		})
+	}
+}
+
+func Test_ConfiguredDefaultsFlowIntoHandlers(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	// 50 bps below redemption value: inside the built-in 100 bps de-peg threshold
+	sources := DataSources{
+		Validators: healthyValidatorSource(),
+		Pegs: PegDataSourceFunc(func(ctx context.Context, tokenAddress string) (*PegQuote, error) {
+			return &PegQuote{MarketPrice: 0.995, RedemptionRate: 1.0}, nil
+		}),
+	}
+
+	t.Setenv(EnvDefaultYieldThreshold, "0.5")
+	t.Setenv(EnvDefaultDepegThresholdBps, "25")
+	t.Setenv(EnvDefaultMinValidatorCount, "2000")
+	cfg, err := LoadPerformerConfig()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	builtIn := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), sources)
+	configured := NewYieldSyncPerformer(logger, cfg, sources)
+
+	handle := func(performer *YieldSyncPerformer, payload TaskPayload, out interface{}) {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("Failed to marshal payload: %v", err)
+		}
+		resp, err := performer.HandleTask(&performerV1.TaskRequest{
+			TaskId:  []byte("configured-defaults-" + payload.Type),
+			Payload: payloadBytes,
+		})
+		if err != nil {
+			t.Fatalf("HandleTask failed for %s: %v", payload.Type, err)
+		}
+		if err := json.Unmarshal(resp.Result, out); err != nil {
+			t.Fatalf("Failed to decode %s result: %v", payload.Type, err)
+		}
+	}
+
+	// testLSTData moves ~2.2% from its baseline, above 1% but below the configured 50%
+	monitoring := TaskPayload{
+		Type:       TaskTypeYieldMonitoring,
+		LSTData:    testLSTData(),
+		Parameters: map[string]interface{}{"pool_address": "0xpool"},
+	}
+	var builtInMonitoring, configuredMonitoring YieldMonitoringResult
+	handle(builtIn, monitoring, &builtInMonitoring)
+	handle(configured, monitoring, &configuredMonitoring)
+
+	if !builtInMonitoring.ThresholdExceeded {
+		t.Errorf("Expected the built-in yield threshold to be exceeded")
+	}
+	if configuredMonitoring.ThresholdExceeded {
+		t.Errorf("Expected the configured yield threshold not to be exceeded")
+	}
+
+	depeg := TaskPayload{
+		Type:       TaskTypeDepegCheck,
+		Parameters: map[string]interface{}{"token_address": "0xlst"},
+	}
+	var builtInDepeg, configuredDepeg DepegCheckResult
+	handle(builtIn, depeg, &builtInDepeg)
+	handle(configured, depeg, &configuredDepeg)
+
+	if builtInDepeg.Depegged || builtInDepeg.ThresholdBps != DefaultDepegThresholdBps {
+		t.Errorf("Expected no de-peg at the built-in threshold, got %+v", builtInDepeg)
+	}
+	if !configuredDepeg.Depegged || configuredDepeg.ThresholdBps != 25 {
+		t.Errorf("Expected a de-peg at the configured 25 bps threshold, got %+v", configuredDepeg)
+	}
+
+	// healthyValidatorSource reports 1250 validators, below the configured minimum of 2000
+	validation := TaskPayload{
+		Type:       TaskTypeLSTValidation,
+		Parameters: map[string]interface{}{"token_address": "0xlst"},
+	}
+	var builtInValidation, configuredValidation LSTValidationResult
+	handle(builtIn, validation, &builtInValidation)
+	handle(configured, validation, &configuredValidation)
+
+	if !builtInValidation.IsValid {
+		t.Errorf("Expected the validator set to pass the built-in minimum, got %v", builtInValidation.Reasons)
+	}
+	if configuredValidation.IsValid {
+		t.Errorf("Expected the validator set to fail the configured minimum of 2000")
+	}
+}
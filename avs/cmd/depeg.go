@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// DefaultDepegThresholdBps is the discount to redemption value that counts as a de-peg
+const DefaultDepegThresholdBps = 100.0 // 1%
+
+// PegQuote is the market price and protocol redemption rate of an LST, both in ETH
+// per token. BlockNumber is the block the prices were observed at, if known.
+type PegQuote struct {
+	MarketPrice    float64 `json:"market_price"`
+	RedemptionRate float64 `json:"redemption_rate"`
+	BlockNumber    uint64  `json:"block_number,omitempty"`
+}
+
+// PegDataSource provides market and redemption prices for an LST token
+type PegDataSource interface {
+	PegQuote(ctx context.Context, tokenAddress string) (*PegQuote, error)
+}
+
+// PegDataSourceFunc adapts a function to the PegDataSource interface
+type PegDataSourceFunc func(ctx context.Context, tokenAddress string) (*PegQuote, error)
+
+func (f PegDataSourceFunc) PegQuote(ctx context.Context, tokenAddress string) (*PegQuote, error) {
+	return f(ctx, tokenAddress)
+}
+
+// HTTPPegDataSource fetches a PegQuote as JSON from an endpoint configured per
+// token, such as a DEX price service or an oracle adapter.
+type HTTPPegDataSource struct {
+	endpoints tokenEndpoints
+	client    *http.Client
+}
+
+func NewHTTPPegDataSource(endpoints map[string]string) *HTTPPegDataSource {
+	return &HTTPPegDataSource{
+		endpoints: newTokenEndpoints(endpoints),
+		client:    &http.Client{Timeout: dataSourceTimeout},
+	}
+}
+
+// SupportsToken reports whether an endpoint is configured for tokenAddress
+func (s *HTTPPegDataSource) SupportsToken(tokenAddress string) bool {
+	return s.endpoints.has(tokenAddress)
+}
+
+func (s *HTTPPegDataSource) PegQuote(ctx context.Context, tokenAddress string) (*PegQuote, error) {
+	var quote PegQuote
+	if err := fetchTokenJSON(ctx, s.client, s.endpoints, tokenAddress, "price", &quote); err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// DepegCheckResult represents the peg status of an LST. DeviationBps is positive
+// when the token trades below its redemption value.
+type DepegCheckResult struct {
+	TokenAddress   string  `json:"token_address"`
+	MarketPrice    float64 `json:"market_price"`
+	RedemptionRate float64 `json:"redemption_rate"`
+	DeviationBps   float64 `json:"deviation_bps"`
+	ThresholdBps   float64 `json:"threshold_bps"`
+	Depegged       bool    `json:"depegged"`
+	BlockNumber    uint64  `json:"block_number,omitempty"`
+	Timestamp      int64   `json:"timestamp,omitempty"`
+}
+
+// evaluatePeg compares the market price against the redemption rate. Only a
+// discount counts as a breach; trading at a premium is not a de-peg.
+func evaluatePeg(tokenAddress string, quote *PegQuote, thresholdBps float64) (*DepegCheckResult, error) {
+	if quote.RedemptionRate <= 0 {
+		return nil, fmt.Errorf("redemption rate for %s must be positive, got %g", tokenAddress, quote.RedemptionRate)
+	}
+	if quote.MarketPrice < 0 {
+		return nil, fmt.Errorf("market price for %s must not be negative, got %g", tokenAddress, quote.MarketPrice)
+	}
+
+	deviation := (quote.RedemptionRate - quote.MarketPrice) / quote.RedemptionRate * 10000
+	deviation = math.Round(deviation*100) / 100
+
+	return &DepegCheckResult{
+		TokenAddress:   tokenAddress,
+		MarketPrice:    quote.MarketPrice,
+		RedemptionRate: quote.RedemptionRate,
+		DeviationBps:   deviation,
+		ThresholdBps:   thresholdBps,
+		Depegged:       deviation >= thresholdBps,
+		BlockNumber:    quote.BlockNumber,
+	}, nil
+}
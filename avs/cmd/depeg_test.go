@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+// atPegSource reports every token trading at its redemption value
+func atPegSource() PegDataSource {
+	return PegDataSourceFunc(func(ctx context.Context, tokenAddress string) (*PegQuote, error) {
+		return &PegQuote{MarketPrice: 1.1, RedemptionRate: 1.1}, nil
+	})
+}
+
+func Test_DepegCheckTask(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		quote         PegQuote
+		params        map[string]interface{}
+		wantDepegged  bool
+		wantDeviation float64
+	}{
+		{
+			name:          "At Peg",
+			quote:         PegQuote{MarketPrice: 1.15, RedemptionRate: 1.15},
+			params:        map[string]interface{}{"token_address": "0xlst"},
+			wantDepegged:  false,
+			wantDeviation: 0,
+		},
+		{
+			name:          "Small Discount",
+			quote:         PegQuote{MarketPrice: 0.995, RedemptionRate: 1.0},
+			params:        map[string]interface{}{"token_address": "0xlst"},
+			wantDepegged:  false,
+			wantDeviation: 50,
+		},
+		{
+			name:          "Depegged",
+			quote:         PegQuote{MarketPrice: 0.94, RedemptionRate: 1.0},
+			params:        map[string]interface{}{"token_address": "0xlst"},
+			wantDepegged:  true,
+			wantDeviation: 600,
+		},
+		{
+			name:          "Custom Threshold",
+			quote:         PegQuote{MarketPrice: 0.995, RedemptionRate: 1.0},
+			params:        map[string]interface{}{"token_address": "0xlst", "threshold_bps": 25.0},
+			wantDepegged:  true,
+			wantDeviation: 50,
+		},
+		{
+			name:          "Premium Is Not A Depeg",
+			quote:         PegQuote{MarketPrice: 1.05, RedemptionRate: 1.0},
+			params:        map[string]interface{}{"token_address": "0xlst"},
+			wantDepegged:  false,
+			wantDeviation: -500,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			quote := tc.quote
+			performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), DataSources{
+				Validators: healthyValidatorSource(),
+				Pegs: PegDataSourceFunc(func(ctx context.Context, tokenAddress string) (*PegQuote, error) {
+					return &quote, nil
+				}),
+			})
+
+			payloadBytes, err := json.Marshal(TaskPayload{
+				Type:       TaskTypeDepegCheck,
+				Parameters: tc.params,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal payload: %v", err)
+			}
+
+			taskRequest := &performerV1.TaskRequest{
+				TaskId:  []byte("depeg-check"),
+				Payload: payloadBytes,
+			}
+
+			if err := performer.ValidateTask(taskRequest); err != nil {
+				t.Fatalf("ValidateTask failed: %v", err)
+			}
+
+			resp, err := performer.HandleTask(taskRequest)
+			if err != nil {
+				t.Fatalf("HandleTask failed: %v", err)
+			}
+
+			var result DepegCheckResult
+			if err := json.Unmarshal(resp.Result, &result); err != nil {
+				t.Fatalf("Failed to decode result: %v", err)
+			}
+
+			if result.Depegged != tc.wantDepegged {
+				t.Errorf("Expected depegged=%v, got %v", tc.wantDepegged, result.Depegged)
+			}
+			if result.DeviationBps != tc.wantDeviation {
+				t.Errorf("Expected deviation %v bps, got %v", tc.wantDeviation, result.DeviationBps)
+			}
+		})
+	}
+}
+
+func Test_DepegCheckValidation(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+	for _, payload := range []string{
+		`{"type":"depeg_check","parameters":{}}`,
+		`{"type":"depeg_check","parameters":{"token_address":"0xlst","threshold_bps":"100"}}`,
+		`{"type":"depeg_check","parameters":{"token_address":"0xlst","threshold_bps":-5}}`,
+	} {
+		err := performer.ValidateTask(&performerV1.TaskRequest{
+			TaskId:  []byte("depeg-validation"),
+			Payload: []byte(payload),
+		})
+		if err == nil {
+			t.Errorf("Expected payload to be rejected: %s", payload)
+		}
+	}
+
+	// A payload quote is checked during validation rather than at handling time
+	err = performer.ValidateTask(&performerV1.TaskRequest{
+		TaskId:  []byte("depeg-validation"),
+		Payload: []byte(`{"type":"depeg_check","parameters":{"token_address":"0xlst"},"peg_quote":{"market_price":1,"redemption_rate":0}}`),
+	})
+	if err == nil {
+		t.Errorf("Expected a payload quote with a zero redemption rate to be rejected")
+	}
+
+	// A token without a configured price source is rejected before it reaches the handler
+	unconfigured := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), DataSources{
+		Validators: healthyValidatorSource(),
+		Pegs:       NewHTTPPegDataSource(nil),
+	})
+	err = unconfigured.ValidateTask(&performerV1.TaskRequest{
+		TaskId:  []byte("depeg-validation"),
+		Payload: []byte(`{"type":"depeg_check","parameters":{"token_address":"0xlst"}}`),
+	})
+	if err == nil {
+		t.Errorf("Expected a token without a price source to be rejected")
+	}
+
+	if _, err := evaluatePeg("0xlst", &PegQuote{MarketPrice: 1, RedemptionRate: 0}, 100); err == nil {
+		t.Errorf("Expected a zero redemption rate to be rejected")
+	}
+}
+
+func Test_DepegCheckUsesPayloadQuote(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	// No price source is configured, so the quote in the payload is the only input
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), DataSources{
+		Validators: healthyValidatorSource(),
+		Pegs:       NewHTTPPegDataSource(nil),
+	})
+
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type:       TaskTypeDepegCheck,
+		Parameters: map[string]interface{}{"token_address": "0xlst"},
+		PegQuote:   &PegQuote{MarketPrice: 0.97, RedemptionRate: 1.0, BlockNumber: 19000000},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+	taskRequest := &performerV1.TaskRequest{TaskId: []byte("depeg-snapshot"), Payload: payloadBytes}
+
+	if err := performer.ValidateTask(taskRequest); err != nil {
+		t.Fatalf("ValidateTask failed: %v", err)
+	}
+	resp, err := performer.HandleTask(taskRequest)
+	if err != nil {
+		t.Fatalf("HandleTask failed: %v", err)
+	}
+
+	var result DepegCheckResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if !result.Depegged || result.DeviationBps != 300 || result.BlockNumber != 19000000 {
+		t.Errorf("Expected a 300 bps de-peg at block 19000000, got %+v", result)
+	}
+}
@@ -21,6 +21,8 @@ const (
 	TaskTypeRiskAssessment     TaskType = "risk_assessment"
 	TaskTypeRebalancing        TaskType = "rebalancing"
 	TaskTypeLSTValidation      TaskType = "lst_validation"
+	TaskTypeDepegCheck         TaskType = "depeg_check"
+	TaskTypeBatch              TaskType = "batch"
 )
 
 // LSTData represents LST yield data
@@ -36,31 +38,57 @@ type LSTData struct {
 // PositionData represents LP position information
 type PositionData struct {
 	PoolId          string   `json:"pool_id"`
-	LowerTick       int24    `json:"lower_tick"`
-	UpperTick       int24    `json:"upper_tick"`
+	LowerTick       int32    `json:"lower_tick"`
+	UpperTick       int32    `json:"upper_tick"`
 	Liquidity       *big.Int `json:"liquidity"`
 	Token0Amount    *big.Int `json:"token0_amount"`
 	Token1Amount    *big.Int `json:"token1_amount"`
 	LastAdjustment  time.Time `json:"last_adjustment"`
 }
 
-// TaskPayload represents the structure of YieldSync task payload data
+// TaskPayload represents the structure of YieldSync task payload data.
+// Timestamp is the Unix time the task was created at; it is echoed into results
+// so that every operator computing the same task produces identical bytes.
+// SubTasks holds the ordered sub-task payloads of a batch task. ValidatorStats
+// and PegQuote carry data observed by the task creator; when set, the LST
+// validation and de-peg handlers use them instead of querying the configured
+// sources, so every operator evaluates the same observation.
 type TaskPayload struct {
 	Type       TaskType               `json:"type"`
 	Parameters map[string]interface{} `json:"parameters"`
 	LSTData    []LSTData             `json:"lst_data,omitempty"`
 	Position   *PositionData         `json:"position,omitempty"`
+	Timestamp  int64                 `json:"timestamp,omitempty"`
+	SubTasks   []TaskPayload          `json:"sub_tasks,omitempty"`
+
+	ValidatorStats *ValidatorStats `json:"validator_stats,omitempty"`
+	PegQuote       *PegQuote       `json:"peg_quote,omitempty"`
 }
 
 // YieldAdjustmentResult represents the result of yield-based position adjustment
 type YieldAdjustmentResult struct {
 	AdjustmentRequired bool      `json:"adjustment_required"`
-	NewLowerTick       int24     `json:"new_lower_tick,omitempty"`
-	NewUpperTick       int24     `json:"new_upper_tick,omitempty"`
+	NewLowerTick       int32     `json:"new_lower_tick,omitempty"`
+	NewUpperTick       int32     `json:"new_upper_tick,omitempty"`
 	ReasonCode         string    `json:"reason_code"`
 	YieldDifference    *big.Int  `json:"yield_difference,omitempty"`
 	RiskAssessment     uint8     `json:"risk_assessment"`
-	Timestamp          time.Time `json:"timestamp"`
+	Timestamp          int64     `json:"timestamp,omitempty"`
+}
+
+// RebalancingResult represents the trades required to restore target allocations.
+// GasEstimate is the cost of the trades in ETH at the task's gas_price_gwei.
+type RebalancingResult struct {
+	RebalanceRequired bool               `json:"rebalance_required"`
+	ReasonCode        string             `json:"reason_code"`
+	TargetAllocation  map[string]float64 `json:"target_allocation"`
+	CurrentAllocation map[string]float64 `json:"current_allocation"`
+	CurrentDeviation  float64            `json:"current_deviation"`
+	Trades            []RebalanceTrade   `json:"trades"`
+	GasUnits          uint64             `json:"gas_units"`
+	GasEstimate       string             `json:"gas_estimate"`
+	ExpectedBenefit   string             `json:"expected_benefit,omitempty"`
+	Timestamp         int64              `json:"timestamp,omitempty"`
 }
 
 // parseTaskPayload extracts and parses the task payload from TaskRequest
@@ -82,16 +110,30 @@ func parseTaskPayload(t *performerV1.TaskRequest) (*TaskPayload, error) {
 // return the result to the Executor where the result is signed and returned to the
 // Aggregator to place in the outbox once the signing threshold is met.
 type YieldSyncPerformer struct {
-	logger     *zap.Logger
-	startTime  time.Time
-	taskCount  uint64
+	logger      *zap.Logger
+	startTime   time.Time
+	taskCount   uint64
+	results     *ResultCache
+	defaults    HandlerDefaults
+	sources     DataSources
+	taskTimeout time.Duration
+}
+
+// DataSources are the external data providers used by task handlers
+type DataSources struct {
+	Validators ValidatorDataSource
+	Pegs       PegDataSource
 }
 
-func NewYieldSyncPerformer(logger *zap.Logger) *YieldSyncPerformer {
+func NewYieldSyncPerformer(logger *zap.Logger, cfg *PerformerConfig, sources DataSources) *YieldSyncPerformer {
 	return &YieldSyncPerformer{
-		logger:    logger,
-		startTime: time.Now(),
-		taskCount: 0,
+		logger:      logger,
+		startTime:   time.Now(),
+		taskCount:   0,
+		results:     NewResultCache(DefaultResultCacheSize, DefaultResultCacheTTL),
+		defaults:    cfg.Defaults,
+		sources:     sources,
+		taskTimeout: cfg.Timeout,
 	}
 }
 
@@ -119,15 +161,30 @@ func (ysp *YieldSyncPerformer) ValidateTask(t *performerV1.TaskRequest) error {
 		return fmt.Errorf("invalid task payload structure: %w", err)
 	}
 
+	if err := ysp.validatePayload(payload); err != nil {
+		return err
+	}
+
+	ysp.logger.Sugar().Infow("YieldSync task validation successful", "taskId", string(t.TaskId), "type", payload.Type)
+	return nil
+}
+
+// validatePayload checks the type, schema, and parameters of a parsed task payload
+func (ysp *YieldSyncPerformer) validatePayload(payload *TaskPayload) error {
 	// Validate task type
 	switch payload.Type {
 	case TaskTypeYieldMonitoring, TaskTypePositionAdjustment, TaskTypeRiskAssessment, 
-		 TaskTypeRebalancing, TaskTypeLSTValidation:
+		 TaskTypeRebalancing, TaskTypeLSTValidation, TaskTypeDepegCheck, TaskTypeBatch:
 		// Valid task types
 	default:
 		return fmt.Errorf("invalid task type: %s", payload.Type)
 	}
 
+	// Validate payload field types and bounds against the task type schema
+	if err := validatePayloadSchema(payload); err != nil {
+		return fmt.Errorf("task payload schema validation failed: %w", err)
+	}
+
 	// Task-specific validation
 	switch payload.Type {
 	case TaskTypeYieldMonitoring:
@@ -150,9 +207,16 @@ func (ysp *YieldSyncPerformer) ValidateTask(t *performerV1.TaskRequest) error {
 		if err := ysp.validateLSTValidationTask(payload); err != nil {
 			return fmt.Errorf("LST validation task validation failed: %w", err)
 		}
+	case TaskTypeDepegCheck:
+		if err := ysp.validateDepegCheckTask(payload); err != nil {
+			return fmt.Errorf("depeg check task validation failed: %w", err)
+		}
+	case TaskTypeBatch:
+		if err := ysp.validateBatchTask(payload); err != nil {
+			return fmt.Errorf("batch task validation failed: %w", err)
+		}
 	}
 
-	ysp.logger.Sugar().Infow("YieldSync task validation successful", "taskId", string(t.TaskId), "type", payload.Type)
 	return nil
 }
 
@@ -161,6 +225,15 @@ func (ysp *YieldSyncPerformer) HandleTask(t *performerV1.TaskRequest) (*performe
 		zap.Any("task", t),
 	)
 
+	// Executor retries of the same task return the previously computed result
+	if cached, ok := ysp.results.Get(t.TaskId, t.Payload); ok {
+		ysp.logger.Sugar().Infow("Returning cached YieldSync task result", "taskId", string(t.TaskId))
+		return &performerV1.TaskResponse{
+			TaskId: t.TaskId,
+			Result: cached,
+		}, nil
+	}
+
 	ysp.taskCount++
 
 	// ------------------------------------------------------------------------
@@ -169,6 +242,7 @@ func (ysp *YieldSyncPerformer) HandleTask(t *performerV1.TaskRequest) (*performe
 	// This is where the Performer will execute YieldSync-specific work
 	
 	var resultBytes []byte
+	var cacheable bool
 	var err error
 
 	// Parse task payload to determine task type
@@ -178,21 +252,11 @@ func (ysp *YieldSyncPerformer) HandleTask(t *performerV1.TaskRequest) (*performe
 	}
 	
 	// Route to appropriate handler based on task type
-	switch payload.Type {
-	case TaskTypeYieldMonitoring:
-		resultBytes, err = ysp.handleYieldMonitoring(t, payload)
-	case TaskTypePositionAdjustment:
-		resultBytes, err = ysp.handlePositionAdjustment(t, payload)
-	case TaskTypeRiskAssessment:
-		resultBytes, err = ysp.handleRiskAssessment(t, payload)
-	case TaskTypeRebalancing:
-		resultBytes, err = ysp.handleRebalancing(t, payload)
-	case TaskTypeLSTValidation:
-		resultBytes, err = ysp.handleLSTValidation(t, payload)
-	default:
-		return nil, fmt.Errorf("unknown task type '%s' for task %s", payload.Type, string(t.TaskId))
-	}
+	// External data sources must answer within the task timeout
+	ctx, cancel := context.WithTimeout(context.Background(), ysp.taskTimeout)
+	defer cancel()
 
+	resultBytes, cacheable, err = ysp.handlePayload(ctx, t, payload)
 	if err != nil {
 		ysp.logger.Sugar().Errorw("YieldSync task processing failed", 
 			"taskId", string(t.TaskId), 
@@ -209,42 +273,53 @@ func (ysp *YieldSyncPerformer) HandleTask(t *performerV1.TaskRequest) (*performe
 		"totalTasksProcessed", ysp.taskCount,
 	)
 
+	if cacheable {
+		ysp.results.Put(t.TaskId, t.Payload, resultBytes)
+	}
+
 	return &performerV1.TaskResponse{
 		TaskId: t.TaskId,
 		Result: resultBytes,
 	}, nil
 }
 
+// handlePayload routes a parsed task payload to the handler for its task type.
+// cacheable reports whether the result may be served to retries of the task.
+func (ysp *YieldSyncPerformer) handlePayload(ctx context.Context, t *performerV1.TaskRequest, payload *TaskPayload) (result []byte, cacheable bool, err error) {
+	switch payload.Type {
+	case TaskTypeYieldMonitoring:
+		result, err = ysp.handleYieldMonitoring(t, payload)
+	case TaskTypePositionAdjustment:
+		result, err = ysp.handlePositionAdjustment(t, payload)
+	case TaskTypeRiskAssessment:
+		result, err = ysp.handleRiskAssessment(t, payload)
+	case TaskTypeRebalancing:
+		result, err = ysp.handleRebalancing(t, payload)
+	case TaskTypeLSTValidation:
+		result, err = ysp.handleLSTValidation(ctx, t, payload)
+	case TaskTypeDepegCheck:
+		result, err = ysp.handleDepegCheck(ctx, t, payload)
+	case TaskTypeBatch:
+		return ysp.handleBatch(ctx, t, payload)
+	default:
+		return nil, false, fmt.Errorf("%w '%s' for task %s", errUnsupportedTaskType, payload.Type, string(t.TaskId))
+	}
+	return result, err == nil, err
+}
+
 // handleYieldMonitoring processes LST yield monitoring tasks
 func (ysp *YieldSyncPerformer) handleYieldMonitoring(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
 	ysp.logger.Sugar().Infow("Processing yield monitoring task", "taskId", string(t.TaskId))
 	
 	// Extract parameters
-	poolAddress, ok := payload.Parameters["pool_address"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing or invalid pool_address parameter")
+	params, err := parseYieldMonitoringParams(payload, ysp.defaults)
+	if err != nil {
+		return nil, fmt.Errorf("invalid yield monitoring parameters: %w", err)
 	}
 
-	threshold, ok := payload.Parameters["threshold"].(float64)
-	if !ok {
-		threshold = 0.01 // Default 1% threshold
-	}
-
-	// Simulate yield monitoring logic
-	// In a real implementation, this would:
-	// - Query current LST yields from various sources
-	// - Compare against historical data
-	// - Detect significant yield changes
-	// - Return monitoring results
-	
-	result := map[string]interface{}{
-		"pool_address": poolAddress,
-		"yield_change_detected": true,
-		"threshold_exceeded": threshold > 0.005,
-		"current_yields": payload.LSTData,
-		"timestamp": time.Now(),
-		"monitoring_status": "active",
-	}
+	// Compare current LST yields against the trailing average of their history
+	result := evaluateYieldChanges(params.PoolAddress, payload.LSTData, params.Threshold, params.BaselineWindow)
+	result.Timestamp = payload.Timestamp
 
 	return json.Marshal(result)
 }
@@ -258,15 +333,16 @@ func (ysp *YieldSyncPerformer) handlePositionAdjustment(t *performerV1.TaskReque
 	}
 
 	// Extract adjustment parameters
-	targetYield, ok := payload.Parameters["target_yield"].(float64)
-	if !ok {
-		targetYield = 0.05 // Default 5% target yield
+	params, err := parsePositionAdjustmentParams(payload, ysp.defaults)
+	if err != nil {
+		return nil, fmt.Errorf("invalid position adjustment parameters: %w", err)
 	}
 
-	maxSlippage, ok := payload.Parameters["max_slippage"].(float64)
-	if !ok {
-		maxSlippage = 0.005 // Default 0.5% max slippage
-	}
+	ysp.logger.Sugar().Debugw("Position adjustment parameters",
+		"taskId", string(t.TaskId),
+		"targetYield", params.TargetYield,
+		"maxSlippage", params.MaxSlippage,
+	)
 
 	// Simulate position adjustment calculation
 	// In a real implementation, this would:
@@ -277,12 +353,12 @@ func (ysp *YieldSyncPerformer) handlePositionAdjustment(t *performerV1.TaskReque
 
 	adjustmentResult := YieldAdjustmentResult{
 		AdjustmentRequired: true,
-		NewLowerTick:      payload.Position.LowerTick - 100, // Example adjustment
-		NewUpperTick:      payload.Position.UpperTick + 100,
+		NewLowerTick:      clampTick(payload.Position.LowerTick - 100), // Example adjustment
+		NewUpperTick:      clampTick(payload.Position.UpperTick + 100),
 		ReasonCode:        "yield_optimization",
 		YieldDifference:   big.NewInt(150), // 1.5% improvement
 		RiskAssessment:    3, // Medium risk
-		Timestamp:         time.Now(),
+		Timestamp:         payload.Timestamp,
 	}
 
 	return json.Marshal(adjustmentResult)
@@ -305,7 +381,7 @@ func (ysp *YieldSyncPerformer) handleRiskAssessment(t *performerV1.TaskRequest,
 		"market_risk": 5,
 		"liquidity_risk": 2,
 		"recommendation": "moderate_exposure",
-		"timestamp": time.Now(),
+		"timestamp": payload.Timestamp,
 	}
 
 	return json.Marshal(riskAssessment)
@@ -316,68 +392,166 @@ func (ysp *YieldSyncPerformer) handleRebalancing(t *performerV1.TaskRequest, pay
 	ysp.logger.Sugar().Infow("Processing rebalancing task", "taskId", string(t.TaskId))
 	
 	// Extract rebalancing parameters
-	rebalanceThreshold, ok := payload.Parameters["rebalance_threshold"].(float64)
-	if !ok {
-		rebalanceThreshold = 0.02 // Default 2% threshold
+	params, err := parseRebalancingParams(payload, ysp.defaults)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rebalancing parameters: %w", err)
 	}
 
-	// Simulate rebalancing logic
-	// In a real implementation, this would:
-	// - Calculate current portfolio allocation
-	// - Compare against target allocation
-	// - Generate rebalancing instructions
-	// - Optimize for gas efficiency
-
-	rebalanceResult := map[string]interface{}{
-		"rebalance_required": true,
-		"target_allocation": map[string]float64{
-			"stETH": 0.4,
-			"rETH":  0.35,
-			"cbETH": 0.25,
-		},
-		"current_deviation": 0.025, // 2.5% deviation
-		"gas_estimate": "0.015", // ETH
-		"timestamp": time.Now(),
+	// Compute the minimal-turnover trades that bring every token back within tolerance
+	plan := planRebalance(params)
+
+	targetAllocation := params.TargetAllocation
+	if len(targetAllocation) == 0 {
+		targetAllocation = DefaultTargetAllocation
+	}
+
+	rebalanceResult := RebalancingResult{
+		RebalanceRequired: plan.RebalanceRequired,
+		ReasonCode:        plan.ReasonCode,
+		TargetAllocation:  targetAllocation,
+		CurrentAllocation: plan.CurrentWeights,
+		CurrentDeviation:  plan.MaxDeviation,
+		Trades:            plan.Trades,
+		GasUnits:          plan.GasUnits,
+		GasEstimate:       formatEth(plan.GasCost),
+		Timestamp:         payload.Timestamp,
+	}
+	if len(params.TokenYields) > 0 {
+		rebalanceResult.ExpectedBenefit = formatEth(plan.ExpectedBenefit)
 	}
 
 	return json.Marshal(rebalanceResult)
 }
 
 // handleLSTValidation processes LST validation tasks
-func (ysp *YieldSyncPerformer) handleLSTValidation(t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
+func (ysp *YieldSyncPerformer) handleLSTValidation(ctx context.Context, t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
 	ysp.logger.Sugar().Infow("Processing LST validation task", "taskId", string(t.TaskId))
 	
 	// Extract validation parameters
-	tokenAddress, ok := payload.Parameters["token_address"].(string)
-	if !ok {
-		return nil, fmt.Errorf("missing token_address parameter")
+	params, err := parseLSTValidationParams(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LST validation parameters: %w", err)
 	}
 
-	// Simulate LST validation logic
-	// In a real implementation, this would:
-	// - Verify LST contract authenticity
-	// - Check validator set health
-	// - Validate yield calculation methods
-	// - Return validation status
+	// Prefer the validator set data observed by the task creator; a live fetch
+	// may differ between operators
+	stats := payload.ValidatorStats
+	if stats == nil {
+		stats, err = ysp.sources.Validators.ValidatorStats(ctx, params.TokenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load validator data: %w: %w", errSourceUnavailable, err)
+		}
+	}
+
+	validationResult := evaluateValidatorStats(params.TokenAddress, stats, ysp.defaults.MinValidatorCount, ysp.defaults.MinHealthScore)
+	validationResult.Timestamp = payload.Timestamp
 
-	validationResult := map[string]interface{}{
-		"token_address": tokenAddress,
-		"is_valid": true,
-		"validator_count": 1250,
-		"health_score": 95,
-		"yield_method_verified": true,
-		"last_slashing_event": nil,
-		"timestamp": time.Now(),
+	if !validationResult.IsValid {
+		ysp.logger.Sugar().Warnw("LST failed validation",
+			"taskId", string(t.TaskId),
+			"token", params.TokenAddress,
+			"reasons", validationResult.Reasons,
+		)
 	}
 
 	return json.Marshal(validationResult)
 }
 
+// handleDepegCheck processes LST de-peg detection tasks
+func (ysp *YieldSyncPerformer) handleDepegCheck(ctx context.Context, t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, error) {
+	ysp.logger.Sugar().Infow("Processing depeg check task", "taskId", string(t.TaskId))
+
+	params, err := parseDepegCheckParams(payload, ysp.defaults)
+	if err != nil {
+		return nil, fmt.Errorf("invalid depeg check parameters: %w", err)
+	}
+
+	// Prefer the prices observed by the task creator; a live fetch may differ between operators
+	quote := payload.PegQuote
+	if quote == nil {
+		quote, err = ysp.sources.Pegs.PegQuote(ctx, params.TokenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load peg prices: %w: %w", errSourceUnavailable, err)
+		}
+	}
+
+	depegResult, err := evaluatePeg(params.TokenAddress, quote, params.ThresholdBps)
+	if err != nil {
+		return nil, err
+	}
+	depegResult.Timestamp = payload.Timestamp
+
+	if depegResult.Depegged {
+		ysp.logger.Sugar().Warnw("LST de-peg detected",
+			"taskId", string(t.TaskId),
+			"token", params.TokenAddress,
+			"deviationBps", depegResult.DeviationBps,
+			"thresholdBps", depegResult.ThresholdBps,
+		)
+	}
+
+	return json.Marshal(depegResult)
+}
+
+// handleBatch runs the sub-tasks of a batch task in order. By default the first
+// failing sub-task fails the whole batch; with continue_on_error set, failures are
+// recorded per sub-task and the remaining sub-tasks still run. Such a partial
+// result is not cacheable, so an Executor retry runs the failed sub-tasks again.
+func (ysp *YieldSyncPerformer) handleBatch(ctx context.Context, t *performerV1.TaskRequest, payload *TaskPayload) ([]byte, bool, error) {
+	ysp.logger.Sugar().Infow("Processing batch task", "taskId", string(t.TaskId), "subTasks", len(payload.SubTasks))
+
+	params, err := parseBatchParams(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid batch parameters: %w", err)
+	}
+
+	batchResult := &BatchResult{
+		Results:   make([]BatchSubTaskResult, 0, len(payload.SubTasks)),
+		Timestamp: payload.Timestamp,
+	}
+
+	for i := range payload.SubTasks {
+		subTask := batchSubTask(payload, i)
+		subResult := BatchSubTaskResult{Index: i, Type: subTask.Type}
+
+		var result []byte
+		if subTask.Type == TaskTypeBatch {
+			err = fmt.Errorf("%w: nested batch tasks are not supported", errUnsupportedTaskType)
+		} else {
+			result, _, err = ysp.handlePayload(ctx, t, subTask)
+		}
+
+		if err != nil {
+			if !params.ContinueOnError {
+				return nil, false, fmt.Errorf("batch sub-task %d (%s) failed: %w", i, subTask.Type, err)
+			}
+			ysp.logger.Sugar().Warnw("Batch sub-task failed",
+				"taskId", string(t.TaskId),
+				"index", i,
+				"type", subTask.Type,
+				"error", err,
+			)
+			subResult.ErrorCode = batchErrorCode(err)
+			batchResult.Failed++
+		} else {
+			subResult.Result = result
+			batchResult.Succeeded++
+		}
+		batchResult.Results = append(batchResult.Results, subResult)
+	}
+
+	result, err := json.Marshal(batchResult)
+	if err != nil {
+		return nil, false, err
+	}
+	return result, batchResult.Failed == 0, nil
+}
+
 // Validation helper functions
 
 func (ysp *YieldSyncPerformer) validateYieldMonitoringTask(payload *TaskPayload) error {
-	if _, ok := payload.Parameters["pool_address"]; !ok {
-		return fmt.Errorf("pool_address parameter required")
+	if _, err := parseYieldMonitoringParams(payload, ysp.defaults); err != nil {
+		return err
 	}
 	return nil
 }
@@ -386,6 +560,9 @@ func (ysp *YieldSyncPerformer) validatePositionAdjustmentTask(payload *TaskPaylo
 	if payload.Position == nil {
 		return fmt.Errorf("position data required")
 	}
+	if _, err := parsePositionAdjustmentParams(payload, ysp.defaults); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -400,12 +577,65 @@ func (ysp *YieldSyncPerformer) validateRebalancingTask(payload *TaskPayload) err
 	if payload.Position == nil {
 		return fmt.Errorf("position data required for rebalancing")
 	}
+	if _, err := parseRebalancingParams(payload, ysp.defaults); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (ysp *YieldSyncPerformer) validateLSTValidationTask(payload *TaskPayload) error {
-	if _, ok := payload.Parameters["token_address"]; !ok {
-		return fmt.Errorf("token_address parameter required")
+	params, err := parseLSTValidationParams(payload)
+	if err != nil {
+		return err
+	}
+	if payload.ValidatorStats == nil && !supportsToken(ysp.sources.Validators, params.TokenAddress) {
+		return &FieldError{
+			Field:   "parameters.token_address",
+			Message: fmt.Sprintf("no validator data source configured for token %s", params.TokenAddress),
+		}
+	}
+	return nil
+}
+
+func (ysp *YieldSyncPerformer) validateDepegCheckTask(payload *TaskPayload) error {
+	params, err := parseDepegCheckParams(payload, ysp.defaults)
+	if err != nil {
+		return err
+	}
+	if payload.PegQuote != nil {
+		if _, err := evaluatePeg(params.TokenAddress, payload.PegQuote, params.ThresholdBps); err != nil {
+			return &FieldError{Field: "peg_quote", Message: err.Error()}
+		}
+	} else if !supportsToken(ysp.sources.Pegs, params.TokenAddress) {
+		return &FieldError{
+			Field:   "parameters.token_address",
+			Message: fmt.Sprintf("no price source configured for token %s", params.TokenAddress),
+		}
+	}
+	return nil
+}
+
+func (ysp *YieldSyncPerformer) validateBatchTask(payload *TaskPayload) error {
+	if _, err := parseBatchParams(payload); err != nil {
+		return err
+	}
+	if len(payload.SubTasks) == 0 {
+		return &FieldError{Field: "sub_tasks", Message: "batch must contain at least one sub-task"}
+	}
+	if len(payload.SubTasks) > MaxBatchSize {
+		return &FieldError{
+			Field:   "sub_tasks",
+			Message: fmt.Sprintf("batch may contain at most %d sub-tasks, got %d", MaxBatchSize, len(payload.SubTasks)),
+		}
+	}
+	for i := range payload.SubTasks {
+		subTask := batchSubTask(payload, i)
+		if subTask.Type == TaskTypeBatch {
+			return fmt.Errorf("sub-task %d: nested batch tasks are not supported", i)
+		}
+		if err := ysp.validatePayload(subTask); err != nil {
+			return fmt.Errorf("sub-task %d: %w", i, err)
+		}
 	}
 	return nil
 }
@@ -414,17 +644,22 @@ func main() {
 	ctx := context.Background()
 	l, _ := zap.NewProduction()
 
-	performer := NewYieldSyncPerformer(l)
+	cfg, err := LoadPerformerConfig()
+	if err != nil {
+		panic(fmt.Errorf("failed to load YieldSync performer config: %w", err))
+	}
+
+	performer := NewYieldSyncPerformer(l, cfg, DataSources{
+		Validators: NewHTTPValidatorDataSource(cfg.ValidatorSources),
+		Pegs:       NewHTTPPegDataSource(cfg.PegSources),
+	})
 
-	pp, err := server.NewPonosPerformerWithRpcServer(&server.PonosPerformerConfig{
-		Port:    8080,
-		Timeout: 10 * time.Second, // Longer timeout for complex calculations
-	}, performer, l)
+	pp, err := server.NewPonosPerformerWithRpcServer(cfg.ServerConfig(), performer, l)
 	if err != nil {
 		panic(fmt.Errorf("failed to create YieldSync performer: %w", err))
 	}
 
-	l.Info("Starting YieldSync Performer on port 8080...")
+	l.Sugar().Infow("Starting YieldSync Performer", "port", cfg.Port, "timeout", cfg.Timeout)
 	l.Info("YieldSync AVS ready to process LST yield monitoring and position adjustment tasks")
 	
 	if err := pp.Start(ctx); err != nil {
@@ -2,15 +2,58 @@ package main
 
 import (
 	"encoding/json"
+	"math/big"
 	"testing"
 
 	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
 	"go.uber.org/zap"
 )
 
-func Test_LVRAuctionTaskRequestPayload(t *testing.T) {
+// testDataSources returns data sources reporting a healthy, at-peg LST
+func testDataSources() DataSources {
+	return DataSources{
+		Validators: healthyValidatorSource(),
+		Pegs:       atPegSource(),
+	}
+}
+
+// testPosition returns a well-formed LP position for task payloads
+func testPosition() *PositionData {
+	return &PositionData{
+		PoolId:       "0xpool",
+		LowerTick:    -600,
+		UpperTick:    600,
+		Liquidity:    big.NewInt(1000000),
+		Token0Amount: big.NewInt(500),
+		Token1Amount: big.NewInt(500),
+	}
+}
+
+// testLSTData returns well-formed LST yield data for task payloads
+func testLSTData() []LSTData {
+	return []LSTData{
+		{
+			TokenAddress:    "0xae7ab96520de3a18e5e111b5eaab095312d7fe84",
+			CurrentYield:    big.NewInt(350),
+			HistoricalYield: []*big.Int{big.NewInt(340), big.NewInt(345)},
+			RiskScore:       2,
+			Validator:       "lido",
+		},
+	}
+}
+
+// testCurrentAllocation returns a drifted portfolio for rebalancing payloads
+func testCurrentAllocation() map[string]interface{} {
+	return map[string]interface{}{
+		"stETH": 60.0,
+		"rETH":  25.0,
+		"cbETH": 15.0,
+	}
+}
+
+func Test_YieldSyncTaskRequestPayload(t *testing.T) {
 	// ------------------------------------------------------------------------
-	// LVR Auction Task Tests
+	// YieldSync Task Tests
 	// ------------------------------------------------------------------------
 
 	logger, err := zap.NewDevelopment()
@@ -18,12 +61,22 @@ func Test_LVRAuctionTaskRequestPayload(t *testing.T) {
 		t.Errorf("Failed to create logger: %v", err)
 	}
 
-	performer := NewLVRAuctionPerformer(logger)
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type: TaskTypeYieldMonitoring,
+		Parameters: map[string]interface{}{
+			"pool_address": "0x1234567890abcdef",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
 
 	// Test basic task validation
 	taskRequest := &performerV1.TaskRequest{
-		TaskId:  []byte("test-lvr-task-id"),
-		Payload: []byte("test-data"),
+		TaskId:  []byte("test-yieldsync-task-id"),
+		Payload: payloadBytes,
 	}
 
 	err = performer.ValidateTask(taskRequest)
@@ -39,52 +92,60 @@ func Test_LVRAuctionTaskRequestPayload(t *testing.T) {
 	t.Logf("Response: %v", resp)
 }
 
-func Test_LVRAuctionTaskTypes(t *testing.T) {
+func Test_YieldSyncTaskTypes(t *testing.T) {
 	logger, err := zap.NewDevelopment()
 	if err != nil {
 		t.Errorf("Failed to create logger: %v", err)
 	}
 
-	performer := NewLVRAuctionPerformer(logger)
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
 
 	testCases := []struct {
 		name     string
 		taskType TaskType
 		params   map[string]interface{}
+		lstData  []LSTData
+		position *PositionData
 	}{
 		{
-			name:     "LVR Monitoring Task",
-			taskType: TaskTypeLVRMonitoring,
+			name:     "Yield Monitoring Task",
+			taskType: TaskTypeYieldMonitoring,
 			params: map[string]interface{}{
 				"pool_address": "0x1234567890abcdef",
-				"threshold":    1000,
+				"threshold":    0.01,
 			},
+			lstData: testLSTData(),
 		},
 		{
-			name:     "Auction Creation Task",
-			taskType: TaskTypeAuctionCreation,
+			name:     "Position Adjustment Task",
+			taskType: TaskTypePositionAdjustment,
 			params: map[string]interface{}{
-				"pool_id":     "0xabcdef",
-				"duration":    3600,
-				"min_bid":     100,
+				"target_yield": 0.05,
+				"max_slippage": 0.005,
 			},
+			position: testPosition(),
+		},
+		{
+			name:     "Risk Assessment Task",
+			taskType: TaskTypeRiskAssessment,
+			params:   map[string]interface{}{},
+			lstData:  testLSTData(),
 		},
 		{
-			name:     "Bid Validation Task",
-			taskType: TaskTypeBidValidation,
+			name:     "Rebalancing Task",
+			taskType: TaskTypeRebalancing,
 			params: map[string]interface{}{
-				"auction_id": "0x123",
-				"bid_amount": 500,
-				"bidder":     "0xbidder",
+				"rebalance_threshold": 0.02,
+				"current_allocation":  testCurrentAllocation(),
+				"gas_price_gwei":      20.0,
 			},
+			position: testPosition(),
 		},
 		{
-			name:     "Settlement Task",
-			taskType: TaskTypeSettlement,
+			name:     "LST Validation Task",
+			taskType: TaskTypeLSTValidation,
 			params: map[string]interface{}{
-				"auction_id": "0x123",
-				"winner":     "0xwinner",
-				"amount":     1000,
+				"token_address": "0xae7ab96520de3a18e5e111b5eaab095312d7fe84",
 			},
 		},
 	}
@@ -95,6 +156,8 @@ func Test_LVRAuctionTaskTypes(t *testing.T) {
 			payload := TaskPayload{
 				Type:       tc.taskType,
 				Parameters: tc.params,
+				LSTData:    tc.lstData,
+				Position:   tc.position,
 			}
 
 			payloadBytes, err := json.Marshal(payload)
@@ -140,7 +203,7 @@ func Test_LVRAuctionTaskTypes(t *testing.T) {
 func Test_TaskPayloadParsing(t *testing.T) {
 	// Test payload parsing functionality
 	testPayload := TaskPayload{
-		Type: TaskTypeLVRMonitoring,
+		Type: TaskTypeYieldMonitoring,
 		Parameters: map[string]interface{}{
 			"pool_address": "0x1234567890abcdef",
 			"threshold":    1000,
@@ -164,8 +227,8 @@ func Test_TaskPayloadParsing(t *testing.T) {
 		return
 	}
 
-	if parsedPayload.Type != TaskTypeLVRMonitoring {
-		t.Errorf("Expected task type %s, got %s", TaskTypeLVRMonitoring, parsedPayload.Type)
+	if parsedPayload.Type != TaskTypeYieldMonitoring {
+		t.Errorf("Expected task type %s, got %s", TaskTypeYieldMonitoring, parsedPayload.Type)
 	}
 
 	if parsedPayload.Parameters["threshold"] != float64(1000) {
@@ -173,4 +236,68 @@ func Test_TaskPayloadParsing(t *testing.T) {
 	}
 
 	t.Logf("Payload parsing test successful: %+v", parsedPayload)
-}
\ No newline at end of file
+}
+
+func Test_HandleTaskResultsAreDeterministic(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	payloads := []TaskPayload{
+		{
+			Type:       TaskTypeYieldMonitoring,
+			Parameters: map[string]interface{}{"pool_address": "0xpool"},
+			LSTData:    testLSTData(),
+		},
+		{
+			Type:       TaskTypePositionAdjustment,
+			Parameters: map[string]interface{}{},
+			Position:   testPosition(),
+		},
+		{
+			Type:       TaskTypeRiskAssessment,
+			Parameters: map[string]interface{}{},
+			LSTData:    testLSTData(),
+		},
+		{
+			Type:       TaskTypeRebalancing,
+			Parameters: map[string]interface{}{"current_allocation": testCurrentAllocation(), "gas_price_gwei": 20.0},
+			Position:   testPosition(),
+		},
+		{
+			Type:       TaskTypeLSTValidation,
+			Parameters: map[string]interface{}{"token_address": "0xlst"},
+		},
+	}
+
+	for _, payload := range payloads {
+		t.Run(string(payload.Type), func(t *testing.T) {
+			payload.Timestamp = 1700000000
+
+			payloadBytes, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatalf("Failed to marshal payload: %v", err)
+			}
+
+			taskRequest := &performerV1.TaskRequest{
+				TaskId:  []byte("deterministic-" + string(payload.Type)),
+				Payload: payloadBytes,
+			}
+
+			// Separate performers stand in for two operators and rule out cache hits
+			first, err := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources()).HandleTask(taskRequest)
+			if err != nil {
+				t.Fatalf("HandleTask failed: %v", err)
+			}
+			second, err := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources()).HandleTask(taskRequest)
+			if err != nil {
+				t.Fatalf("HandleTask failed: %v", err)
+			}
+
+			if string(first.Result) != string(second.Result) {
+				t.Errorf("Expected identical results\nfirst:  %s\nsecond: %s", first.Result, second.Result)
+			}
+		})
+	}
+}
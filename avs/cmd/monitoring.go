@@ -0,0 +1,142 @@
+package main
+
+import (
+	"math/big"
+)
+
+// Monitoring status values reported in YieldMonitoringResult
+const (
+	MonitoringStatusActive           = "active"
+	MonitoringStatusInsufficientData = "insufficient_data"
+)
+
+// Direction of a token's current yield relative to its baseline
+const (
+	YieldDirectionRising  = "rising"
+	YieldDirectionFalling = "falling"
+	YieldDirectionFlat    = "flat"
+)
+
+// DefaultBaselineWindow is the number of trailing historical samples averaged into the baseline
+const DefaultBaselineWindow = 7
+
+// TokenYieldChange compares one token's current yield with the trailing average of
+// its last BaselineSamples historical yields. RelativeChange is |Delta| / Baseline
+// and is zero when the baseline is zero.
+type TokenYieldChange struct {
+	TokenAddress      string   `json:"token_address"`
+	CurrentYield      *big.Int `json:"current_yield"`
+	BaselineYield     float64  `json:"baseline_yield"`
+	BaselineSamples   int      `json:"baseline_samples"`
+	Delta             float64  `json:"delta"`
+	RelativeChange    float64  `json:"relative_change"`
+	Direction         string   `json:"direction"`
+	ThresholdExceeded bool     `json:"threshold_exceeded"`
+}
+
+// YieldMonitoringResult represents the outcome of comparing current LST yields
+// against their history. MaxRelativeChange is the largest RelativeChange across
+// TokenChanges; tokens without a current yield or usable history are left out.
+type YieldMonitoringResult struct {
+	PoolAddress         string             `json:"pool_address"`
+	YieldChangeDetected bool               `json:"yield_change_detected"`
+	ThresholdExceeded   bool               `json:"threshold_exceeded"`
+	MaxRelativeChange   float64            `json:"max_relative_change"`
+	TokenChanges        []TokenYieldChange `json:"token_changes"`
+	CurrentYields       []LSTData          `json:"current_yields"`
+	MonitoringStatus    string             `json:"monitoring_status"`
+	Timestamp           int64              `json:"timestamp,omitempty"`
+}
+
+// evaluateYieldChanges compares each token's current yield with the trailing
+// average of up to window historical yields (all of them if window <= 0) and
+// flags tokens whose relative change exceeds the threshold. Nil history entries
+// are ignored.
+func evaluateYieldChanges(poolAddress string, lstData []LSTData, threshold float64, window int) *YieldMonitoringResult {
+	result := &YieldMonitoringResult{
+		PoolAddress:      poolAddress,
+		TokenChanges:     []TokenYieldChange{},
+		CurrentYields:    lstData,
+		MonitoringStatus: MonitoringStatusInsufficientData,
+	}
+
+	for _, lst := range lstData {
+		if lst.CurrentYield == nil {
+			continue
+		}
+		baseline, samples := trailingAverage(lst.HistoricalYield, window)
+		if samples == 0 {
+			continue
+		}
+		result.MonitoringStatus = MonitoringStatusActive
+
+		change := compareToBaseline(lst.CurrentYield, baseline)
+		change.TokenAddress = lst.TokenAddress
+		change.BaselineSamples = samples
+
+		// A yield appearing from a zero baseline has no relative size but is always significant
+		if baseline.Sign() == 0 {
+			change.ThresholdExceeded = change.Direction != YieldDirectionFlat
+		} else {
+			change.ThresholdExceeded = change.RelativeChange > threshold
+		}
+
+		if change.Direction != YieldDirectionFlat {
+			result.YieldChangeDetected = true
+		}
+		if change.ThresholdExceeded {
+			result.ThresholdExceeded = true
+		}
+		if change.RelativeChange > result.MaxRelativeChange {
+			result.MaxRelativeChange = change.RelativeChange
+		}
+		result.TokenChanges = append(result.TokenChanges, change)
+	}
+
+	return result
+}
+
+// trailingAverage returns the mean of the last window non-nil values and the number
+// of values averaged
+func trailingAverage(history []*big.Int, window int) (*big.Rat, int) {
+	sum := new(big.Int)
+	samples := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		if window > 0 && samples == window {
+			break
+		}
+		if history[i] == nil {
+			continue
+		}
+		sum.Add(sum, history[i])
+		samples++
+	}
+	if samples == 0 {
+		return nil, 0
+	}
+	return new(big.Rat).SetFrac(sum, big.NewInt(int64(samples))), samples
+}
+
+// compareToBaseline computes the delta, relative change, and direction of current against baseline
+func compareToBaseline(current *big.Int, baseline *big.Rat) TokenYieldChange {
+	delta := new(big.Rat).Sub(new(big.Rat).SetInt(current), baseline)
+
+	change := TokenYieldChange{CurrentYield: current}
+	change.BaselineYield, _ = baseline.Float64()
+	change.Delta, _ = delta.Float64()
+
+	switch delta.Sign() {
+	case 1:
+		change.Direction = YieldDirectionRising
+	case -1:
+		change.Direction = YieldDirectionFalling
+	default:
+		change.Direction = YieldDirectionFlat
+	}
+
+	if baseline.Sign() != 0 {
+		ratio := new(big.Rat).Quo(delta.Abs(delta), baseline)
+		change.RelativeChange, _ = ratio.Float64()
+	}
+	return change
+}
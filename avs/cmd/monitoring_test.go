@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+func Test_YieldMonitoringThreshold(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	testCases := []struct {
+		name              string
+		current           int64
+		previous          int64
+		wantChange        bool
+		wantExceeded      bool
+		wantRelativeDelta float64
+	}{
+		{name: "Unchanged", current: 350, previous: 350, wantChange: false, wantExceeded: false, wantRelativeDelta: 0},
+		{name: "Small Change", current: 352, previous: 350, wantChange: true, wantExceeded: false, wantRelativeDelta: 2.0 / 350},
+		{name: "Large Change", current: 400, previous: 350, wantChange: true, wantExceeded: true, wantRelativeDelta: 50.0 / 350},
+		{name: "Large Drop", current: 300, previous: 350, wantChange: true, wantExceeded: true, wantRelativeDelta: 50.0 / 350},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+			payloadBytes, err := json.Marshal(TaskPayload{
+				Type:       TaskTypeYieldMonitoring,
+				Parameters: map[string]interface{}{"pool_address": "0xpool", "threshold": 0.01},
+				LSTData: []LSTData{{
+					TokenAddress:    "0xlst",
+					CurrentYield:    big.NewInt(tc.current),
+					HistoricalYield: []*big.Int{big.NewInt(tc.previous)},
+				}},
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal payload: %v", err)
+			}
+
+			resp, err := performer.HandleTask(&performerV1.TaskRequest{
+				TaskId:  []byte("yield-monitoring"),
+				Payload: payloadBytes,
+			})
+			if err != nil {
+				t.Fatalf("HandleTask failed: %v", err)
+			}
+
+			var result YieldMonitoringResult
+			if err := json.Unmarshal(resp.Result, &result); err != nil {
+				t.Fatalf("Failed to decode result: %v", err)
+			}
+
+			if result.YieldChangeDetected != tc.wantChange {
+				t.Errorf("Expected yield_change_detected=%v, got %v", tc.wantChange, result.YieldChangeDetected)
+			}
+			if result.ThresholdExceeded != tc.wantExceeded {
+				t.Errorf("Expected threshold_exceeded=%v, got %v", tc.wantExceeded, result.ThresholdExceeded)
+			}
+			if result.MaxRelativeChange != tc.wantRelativeDelta {
+				t.Errorf("Expected max_relative_change=%v, got %v", tc.wantRelativeDelta, result.MaxRelativeChange)
+			}
+			if result.MonitoringStatus != MonitoringStatusActive {
+				t.Errorf("Expected status %s, got %s", MonitoringStatusActive, result.MonitoringStatus)
+			}
+		})
+	}
+}
+
+func Test_YieldMonitoringWithoutHistory(t *testing.T) {
+	result := evaluateYieldChanges("0xpool", []LSTData{
+		{TokenAddress: "0xlst", CurrentYield: big.NewInt(350)},
+		{TokenAddress: "0xnil", CurrentYield: big.NewInt(350), HistoricalYield: []*big.Int{nil, nil}},
+	}, 0.01, DefaultBaselineWindow)
+
+	if result.YieldChangeDetected || result.ThresholdExceeded {
+		t.Errorf("Expected no change without history, got %+v", result)
+	}
+	if result.MonitoringStatus != MonitoringStatusInsufficientData {
+		t.Errorf("Expected status %s, got %s", MonitoringStatusInsufficientData, result.MonitoringStatus)
+	}
+
+	if len(result.TokenChanges) != 0 {
+		t.Errorf("Expected no token changes without usable history, got %+v", result.TokenChanges)
+	}
+
+	fromZero := evaluateYieldChanges("0xpool", []LSTData{
+		{TokenAddress: "0xlst", CurrentYield: big.NewInt(5), HistoricalYield: []*big.Int{big.NewInt(0)}},
+	}, 0.01, DefaultBaselineWindow)
+	if !fromZero.ThresholdExceeded {
+		t.Errorf("Expected a yield appearing from zero to exceed the threshold")
+	}
+}
+
+func Test_YieldMonitoringTrailingBaseline(t *testing.T) {
+	series := func(values ...int64) []*big.Int {
+		out := make([]*big.Int, len(values))
+		for i, v := range values {
+			out[i] = big.NewInt(v)
+		}
+		return out
+	}
+
+	lstData := []LSTData{
+		// Rising: baseline of the last 3 samples (330, 340, 350) is 340
+		{TokenAddress: "0xrising", CurrentYield: big.NewInt(380), HistoricalYield: series(100, 330, 340, 350)},
+		// Falling: baseline 400, current 390 is a 2.5% drop
+		{TokenAddress: "0xfalling", CurrentYield: big.NewInt(390), HistoricalYield: series(400, 400, 400)},
+		// Flat: current equals the baseline; nil samples are skipped
+		{TokenAddress: "0xflat", CurrentYield: big.NewInt(300), HistoricalYield: []*big.Int{big.NewInt(290), nil, big.NewInt(310)}},
+	}
+
+	result := evaluateYieldChanges("0xpool", lstData, 0.05, 3)
+
+	if len(result.TokenChanges) != 3 {
+		t.Fatalf("Expected 3 token changes, got %d", len(result.TokenChanges))
+	}
+
+	expected := []struct {
+		direction string
+		baseline  float64
+		samples   int
+		delta     float64
+		exceeded  bool
+	}{
+		{direction: YieldDirectionRising, baseline: 340, samples: 3, delta: 40, exceeded: true},
+		{direction: YieldDirectionFalling, baseline: 400, samples: 3, delta: -10, exceeded: false},
+		{direction: YieldDirectionFlat, baseline: 300, samples: 2, delta: 0, exceeded: false},
+	}
+
+	for i, want := range expected {
+		got := result.TokenChanges[i]
+		if got.Direction != want.direction {
+			t.Errorf("%s: expected direction %s, got %s", got.TokenAddress, want.direction, got.Direction)
+		}
+		if got.BaselineYield != want.baseline || got.BaselineSamples != want.samples {
+			t.Errorf("%s: expected baseline %v over %d samples, got %v over %d", got.TokenAddress, want.baseline, want.samples, got.BaselineYield, got.BaselineSamples)
+		}
+		if got.Delta != want.delta {
+			t.Errorf("%s: expected delta %v, got %v", got.TokenAddress, want.delta, got.Delta)
+		}
+		if got.ThresholdExceeded != want.exceeded {
+			t.Errorf("%s: expected threshold_exceeded=%v, got %v", got.TokenAddress, want.exceeded, got.ThresholdExceeded)
+		}
+	}
+
+	if !result.YieldChangeDetected || !result.ThresholdExceeded {
+		t.Errorf("Expected the rising token to trip the pool-level flags, got %+v", result)
+	}
+	if result.MaxRelativeChange != 40.0/340 {
+		t.Errorf("Expected max relative change %v, got %v", 40.0/340, result.MaxRelativeChange)
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Default handler parameters applied when a task omits them
+const (
+	DefaultYieldThreshold     = 0.01  // 1% yield change
+	DefaultTargetYield        = 0.05  // 5% target yield
+	DefaultMaxSlippage        = 0.005 // 0.5% max slippage
+	DefaultRebalanceThreshold = 0.02  // 2% allocation drift
+	DefaultBenefitHorizonDays = 30.0  // days
+)
+
+// Upper bounds of the numeric task parameters. Values above these are almost
+// certainly unit mistakes (e.g. a percentage passed where a fraction is expected).
+const (
+	MaxYieldThreshold     = 1.0     // 100% relative yield change
+	MaxBaselineWindow     = 365     // historical samples
+	MaxTargetYield        = 1.0     // 100% yield
+	MaxSlippageLimit      = 0.1     // 10% slippage
+	MaxRebalanceThreshold = 1.0     // 100% allocation drift
+	MaxMinTradeSize       = 1e6     // ETH
+	MaxAllocationAmount   = 1e9     // ETH per token, above the total ETH supply
+	MaxGasPriceGwei       = 10000.0 // gwei
+	MaxBenefitHorizonDays = 3650.0  // 10 years
+	MaxTokenYield         = 1.0     // 100% annual yield, either sign
+	MaxDepegThresholdBps  = 10000.0 // 100%
+	MaxMinValidatorCount  = 1e6     // validators
+	MaxHealthScore        = 100.0   // attestation effectiveness
+)
+
+// HandlerDefaults are the parameter values task handlers fall back to when a
+// task omits them, and the validator-set thresholds applied to LST validation.
+// They are loaded with the PerformerConfig at startup.
+type HandlerDefaults struct {
+	YieldThreshold     float64
+	BaselineWindow     int
+	TargetYield        float64
+	MaxSlippage        float64
+	RebalanceThreshold float64
+	BenefitHorizonDays float64
+	DepegThresholdBps  float64
+	MinValidatorCount  uint64
+	MinHealthScore     float64
+}
+
+// DefaultHandlerDefaults returns the built-in handler parameter defaults
+func DefaultHandlerDefaults() HandlerDefaults {
+	return HandlerDefaults{
+		YieldThreshold:     DefaultYieldThreshold,
+		BaselineWindow:     DefaultBaselineWindow,
+		TargetYield:        DefaultTargetYield,
+		MaxSlippage:        DefaultMaxSlippage,
+		RebalanceThreshold: DefaultRebalanceThreshold,
+		BenefitHorizonDays: DefaultBenefitHorizonDays,
+		DepegThresholdBps:  DefaultDepegThresholdBps,
+		MinValidatorCount:  DefaultMinValidatorCount,
+		MinHealthScore:     DefaultMinHealthScore,
+	}
+}
+
+// Validate checks that every default lies within the bounds accepted for the
+// corresponding task parameter
+func (d HandlerDefaults) Validate() error {
+	checks := []error{
+		checkPositive("yield_threshold", d.YieldThreshold, MaxYieldThreshold),
+		checkNonNegative("baseline_window", float64(d.BaselineWindow), MaxBaselineWindow),
+		checkPositive("target_yield", d.TargetYield, MaxTargetYield),
+		checkPositive("max_slippage", d.MaxSlippage, MaxSlippageLimit),
+		checkPositive("rebalance_threshold", d.RebalanceThreshold, MaxRebalanceThreshold),
+		checkPositive("benefit_horizon_days", d.BenefitHorizonDays, MaxBenefitHorizonDays),
+		checkPositive("depeg_threshold_bps", d.DepegThresholdBps, MaxDepegThresholdBps),
+		checkNonNegative("min_validator_count", float64(d.MinValidatorCount), MaxMinValidatorCount),
+		checkNonNegative("min_health_score", d.MinHealthScore, MaxHealthScore),
+	}
+	return errors.Join(checks...)
+}
+
+// checkPositive returns a FieldError unless 0 < value <= max
+func checkPositive(field string, value, max float64) error {
+	if value <= 0 || value > max || math.IsNaN(value) {
+		return &FieldError{Field: field, Message: fmt.Sprintf("must be greater than 0 and at most %g, got %g", max, value)}
+	}
+	return nil
+}
+
+// checkNonNegative returns a FieldError unless 0 <= value <= max
+func checkNonNegative(field string, value, max float64) error {
+	if value < 0 || value > max || math.IsNaN(value) {
+		return &FieldError{Field: field, Message: fmt.Sprintf("must be between 0 and %g, got %g", max, value)}
+	}
+	return nil
+}
+
+// YieldMonitoringParams are the parameters of a yield monitoring task
+type YieldMonitoringParams struct {
+	PoolAddress    string  `json:"pool_address"`
+	Threshold      float64 `json:"threshold"`
+	BaselineWindow int     `json:"baseline_window"`
+}
+
+// PositionAdjustmentParams are the parameters of a position adjustment task
+type PositionAdjustmentParams struct {
+	TargetYield float64 `json:"target_yield"`
+	MaxSlippage float64 `json:"max_slippage"`
+}
+
+// RebalancingParams are the parameters of a rebalancing task. CurrentAllocation
+// holds the ETH value of each token in the portfolio, TargetAllocation the target
+// weight of each token (summing to 1), and MaxDrift optional per-token overrides
+// of RebalanceThreshold. TokenYields holds the annual yield of each token; when
+// set, rebalancing is suppressed unless the yield gained over BenefitHorizonDays
+// exceeds the gas cost at GasPriceGwei. GasPriceGwei is required: it is the gas
+// price the task creator observed, so every operator costs trades identically.
+type RebalancingParams struct {
+	RebalanceThreshold float64            `json:"rebalance_threshold"`
+	CurrentAllocation  map[string]float64 `json:"current_allocation"`
+	TargetAllocation   map[string]float64 `json:"target_allocation"`
+	MinTradeSize       float64            `json:"min_trade_size"`
+	MaxDrift           map[string]float64 `json:"max_drift"`
+	GasPriceGwei       float64            `json:"gas_price_gwei"`
+	BenefitHorizonDays float64            `json:"benefit_horizon_days"`
+	TokenYields        map[string]float64 `json:"token_yields"`
+}
+
+// LSTValidationParams are the parameters of an LST validation task
+type LSTValidationParams struct {
+	TokenAddress string `json:"token_address"`
+}
+
+// DepegCheckParams are the parameters of a de-peg check task
+type DepegCheckParams struct {
+	TokenAddress string  `json:"token_address"`
+	ThresholdBps float64 `json:"threshold_bps"`
+}
+
+// BatchParams are the parameters of a batch task
+type BatchParams struct {
+	ContinueOnError bool `json:"continue_on_error"`
+}
+
+// decodeParameters decodes the loosely-typed task parameters into a typed struct.
+// Fields already set on out act as defaults for parameters the task omits.
+func decodeParameters(parameters map[string]interface{}, out interface{}) error {
+	raw, err := json.Marshal(parameters)
+	if err != nil {
+		return fmt.Errorf("failed to encode task parameters: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return &FieldError{
+				Field:   "parameters." + typeErr.Field,
+				Message: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value),
+			}
+		}
+		return fmt.Errorf("failed to decode task parameters: %w", err)
+	}
+	return nil
+}
+
+func parseYieldMonitoringParams(payload *TaskPayload, defaults HandlerDefaults) (*YieldMonitoringParams, error) {
+	params := &YieldMonitoringParams{
+		Threshold:      defaults.YieldThreshold,
+		BaselineWindow: defaults.BaselineWindow,
+	}
+	if err := decodeParameters(payload.Parameters, params); err != nil {
+		return nil, err
+	}
+	if params.PoolAddress == "" {
+		return nil, &FieldError{Field: "parameters.pool_address", Message: "required field is missing"}
+	}
+	if err := checkPositive("parameters.threshold", params.Threshold, MaxYieldThreshold); err != nil {
+		return nil, err
+	}
+	if err := checkNonNegative("parameters.baseline_window", float64(params.BaselineWindow), MaxBaselineWindow); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func parsePositionAdjustmentParams(payload *TaskPayload, defaults HandlerDefaults) (*PositionAdjustmentParams, error) {
+	params := &PositionAdjustmentParams{
+		TargetYield: defaults.TargetYield,
+		MaxSlippage: defaults.MaxSlippage,
+	}
+	if err := decodeParameters(payload.Parameters, params); err != nil {
+		return nil, err
+	}
+	if err := checkPositive("parameters.target_yield", params.TargetYield, MaxTargetYield); err != nil {
+		return nil, err
+	}
+	if err := checkPositive("parameters.max_slippage", params.MaxSlippage, MaxSlippageLimit); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func parseRebalancingParams(payload *TaskPayload, defaults HandlerDefaults) (*RebalancingParams, error) {
+	params := &RebalancingParams{
+		RebalanceThreshold: defaults.RebalanceThreshold,
+		BenefitHorizonDays: defaults.BenefitHorizonDays,
+	}
+	if err := decodeParameters(payload.Parameters, params); err != nil {
+		return nil, err
+	}
+
+	if err := checkPositive("parameters.rebalance_threshold", params.RebalanceThreshold, MaxRebalanceThreshold); err != nil {
+		return nil, err
+	}
+	if err := checkNonNegative("parameters.min_trade_size", params.MinTradeSize, MaxMinTradeSize); err != nil {
+		return nil, err
+	}
+
+	if len(params.CurrentAllocation) == 0 {
+		return nil, &FieldError{Field: "parameters.current_allocation", Message: "required field is missing"}
+	}
+	total := 0.0
+	for _, token := range sortedTokens(params.CurrentAllocation) {
+		amount := params.CurrentAllocation[token]
+		if err := checkNonNegative("parameters.current_allocation."+token, amount, MaxAllocationAmount); err != nil {
+			return nil, err
+		}
+		total += amount
+	}
+	if total == 0 {
+		return nil, &FieldError{Field: "parameters.current_allocation", Message: "portfolio value must be positive"}
+	}
+
+	if len(params.TargetAllocation) > 0 {
+		weights := 0.0
+		for _, token := range sortedTokens(params.TargetAllocation) {
+			weight := params.TargetAllocation[token]
+			if weight < 0 {
+				return nil, &FieldError{Field: "parameters.target_allocation." + token, Message: "must not be negative"}
+			}
+			weights += weight
+		}
+		if math.Abs(weights-1) > 1e-6 {
+			return nil, &FieldError{
+				Field:   "parameters.target_allocation",
+				Message: fmt.Sprintf("weights must sum to 1, got %g", weights),
+			}
+		}
+	}
+
+	for _, token := range sortedTokens(params.MaxDrift) {
+		if err := checkNonNegative("parameters.max_drift."+token, params.MaxDrift[token], MaxRebalanceThreshold); err != nil {
+			return nil, err
+		}
+	}
+	for _, token := range sortedTokens(params.TokenYields) {
+		if yield := params.TokenYields[token]; math.Abs(yield) > MaxTokenYield {
+			return nil, &FieldError{
+				Field:   "parameters.token_yields." + token,
+				Message: fmt.Sprintf("must be between %g and %g, got %g", -MaxTokenYield, MaxTokenYield, yield),
+			}
+		}
+	}
+
+	if _, ok := payload.Parameters["gas_price_gwei"]; !ok {
+		return nil, &FieldError{Field: "parameters.gas_price_gwei", Message: "required field is missing"}
+	}
+	if err := checkNonNegative("parameters.gas_price_gwei", params.GasPriceGwei, MaxGasPriceGwei); err != nil {
+		return nil, err
+	}
+	if err := checkPositive("parameters.benefit_horizon_days", params.BenefitHorizonDays, MaxBenefitHorizonDays); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func parseLSTValidationParams(payload *TaskPayload) (*LSTValidationParams, error) {
+	params := &LSTValidationParams{}
+	if err := decodeParameters(payload.Parameters, params); err != nil {
+		return nil, err
+	}
+	if params.TokenAddress == "" {
+		return nil, &FieldError{Field: "parameters.token_address", Message: "required field is missing"}
+	}
+	return params, nil
+}
+
+func parseDepegCheckParams(payload *TaskPayload, defaults HandlerDefaults) (*DepegCheckParams, error) {
+	params := &DepegCheckParams{ThresholdBps: defaults.DepegThresholdBps}
+	if err := decodeParameters(payload.Parameters, params); err != nil {
+		return nil, err
+	}
+	if params.TokenAddress == "" {
+		return nil, &FieldError{Field: "parameters.token_address", Message: "required field is missing"}
+	}
+	if err := checkPositive("parameters.threshold_bps", params.ThresholdBps, MaxDepegThresholdBps); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func parseBatchParams(payload *TaskPayload) (*BatchParams, error) {
+	params := &BatchParams{}
+	if err := decodeParameters(payload.Parameters, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+func Test_ParseParamsAppliesDefaults(t *testing.T) {
+	payload := &TaskPayload{
+		Type:       TaskTypeYieldMonitoring,
+		Parameters: map[string]interface{}{"pool_address": "0xpool"},
+	}
+
+	monitoring, err := parseYieldMonitoringParams(payload, DefaultHandlerDefaults())
+	if err != nil {
+		t.Fatalf("Failed to parse yield monitoring params: %v", err)
+	}
+	if monitoring.Threshold != DefaultYieldThreshold {
+		t.Errorf("Expected default threshold %v, got %v", DefaultYieldThreshold, monitoring.Threshold)
+	}
+
+	adjustment, err := parsePositionAdjustmentParams(&TaskPayload{Parameters: map[string]interface{}{"max_slippage": 0.01}}, DefaultHandlerDefaults())
+	if err != nil {
+		t.Fatalf("Failed to parse position adjustment params: %v", err)
+	}
+	if adjustment.TargetYield != DefaultTargetYield {
+		t.Errorf("Expected default target yield %v, got %v", DefaultTargetYield, adjustment.TargetYield)
+	}
+	if adjustment.MaxSlippage != 0.01 {
+		t.Errorf("Expected max slippage 0.01, got %v", adjustment.MaxSlippage)
+	}
+}
+
+func Test_WronglyTypedThresholdIsRejected(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+	payload := &TaskPayload{
+		Type: TaskTypeYieldMonitoring,
+		Parameters: map[string]interface{}{
+			"pool_address": "0xpool",
+			"threshold":    "0.05",
+		},
+	}
+
+	// Decoding surfaces the mismatch instead of falling back to the default
+	_, err = parseYieldMonitoringParams(payload, DefaultHandlerDefaults())
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("Expected FieldError, got %v", err)
+	}
+	if fieldErr.Field != "parameters.threshold" {
+		t.Errorf("Expected error on parameters.threshold, got %s", fieldErr.Field)
+	}
+
+	// Handlers reject the task even when validation was skipped
+	taskRequest := &performerV1.TaskRequest{TaskId: []byte("typed-params-test")}
+	if _, err := performer.handleYieldMonitoring(taskRequest, payload); err == nil {
+		t.Errorf("Expected handleYieldMonitoring to reject a string threshold")
+	}
+
+	if err := performer.validateYieldMonitoringTask(payload); err == nil {
+		t.Errorf("Expected validateYieldMonitoringTask to reject a string threshold")
+	}
+}
+
+func Test_ParseParamsRequiresAddresses(t *testing.T) {
+	if _, err := parseYieldMonitoringParams(&TaskPayload{Parameters: map[string]interface{}{}}, DefaultHandlerDefaults()); err == nil {
+		t.Errorf("Expected missing pool_address to be rejected")
+	}
+
+	if _, err := parseLSTValidationParams(&TaskPayload{Parameters: map[string]interface{}{"token_address": ""}}); err == nil {
+		t.Errorf("Expected empty token_address to be rejected")
+	}
+}
+
+func Test_ParseParamsRejectsOutOfRangeValues(t *testing.T) {
+	allocation := map[string]interface{}{"stETH": 1.0}
+
+	testCases := []struct {
+		name       string
+		taskType   TaskType
+		parameters map[string]interface{}
+		field      string
+	}{
+		{name: "Negative Threshold", taskType: TaskTypeYieldMonitoring, parameters: map[string]interface{}{"pool_address": "0xpool", "threshold": -0.01}, field: "parameters.threshold"},
+		{name: "Zero Threshold", taskType: TaskTypeYieldMonitoring, parameters: map[string]interface{}{"pool_address": "0xpool", "threshold": 0.0}, field: "parameters.threshold"},
+		{name: "Threshold Over Range", taskType: TaskTypeYieldMonitoring, parameters: map[string]interface{}{"pool_address": "0xpool", "threshold": 5.0}, field: "parameters.threshold"},
+		{name: "Negative Baseline Window", taskType: TaskTypeYieldMonitoring, parameters: map[string]interface{}{"pool_address": "0xpool", "baseline_window": -1.0}, field: "parameters.baseline_window"},
+		{name: "Baseline Window Over Range", taskType: TaskTypeYieldMonitoring, parameters: map[string]interface{}{"pool_address": "0xpool", "baseline_window": 1000.0}, field: "parameters.baseline_window"},
+		{name: "Negative Target Yield", taskType: TaskTypePositionAdjustment, parameters: map[string]interface{}{"target_yield": -0.05}, field: "parameters.target_yield"},
+		{name: "Zero Target Yield", taskType: TaskTypePositionAdjustment, parameters: map[string]interface{}{"target_yield": 0.0}, field: "parameters.target_yield"},
+		{name: "Target Yield Over Range", taskType: TaskTypePositionAdjustment, parameters: map[string]interface{}{"target_yield": 5.0}, field: "parameters.target_yield"},
+		{name: "Negative Max Slippage", taskType: TaskTypePositionAdjustment, parameters: map[string]interface{}{"max_slippage": -0.005}, field: "parameters.max_slippage"},
+		{name: "Zero Max Slippage", taskType: TaskTypePositionAdjustment, parameters: map[string]interface{}{"max_slippage": 0.0}, field: "parameters.max_slippage"},
+		{name: "Max Slippage Over Range", taskType: TaskTypePositionAdjustment, parameters: map[string]interface{}{"max_slippage": 5.0}, field: "parameters.max_slippage"},
+		{name: "Negative Rebalance Threshold", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 20.0, "rebalance_threshold": -0.02}, field: "parameters.rebalance_threshold"},
+		{name: "Zero Rebalance Threshold", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 20.0, "rebalance_threshold": 0.0}, field: "parameters.rebalance_threshold"},
+		{name: "Rebalance Threshold Over Range", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 20.0, "rebalance_threshold": 2.0}, field: "parameters.rebalance_threshold"},
+		{name: "Negative Min Trade Size", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 20.0, "min_trade_size": -1.0}, field: "parameters.min_trade_size"},
+		{name: "Max Drift Over Range", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 20.0, "max_drift": map[string]interface{}{"stETH": 1.5}}, field: "parameters.max_drift.stETH"},
+		{name: "Token Yield Over Range", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 20.0, "token_yields": map[string]interface{}{"stETH": 3.5}}, field: "parameters.token_yields.stETH"},
+		{name: "Negative Gas Price", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": -1.0}, field: "parameters.gas_price_gwei"},
+		{name: "Gas Price Over Range", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 1e9}, field: "parameters.gas_price_gwei"},
+		{name: "Zero Benefit Horizon", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 20.0, "benefit_horizon_days": 0.0}, field: "parameters.benefit_horizon_days"},
+		{name: "Benefit Horizon Over Range", taskType: TaskTypeRebalancing, parameters: map[string]interface{}{"current_allocation": allocation, "gas_price_gwei": 20.0, "benefit_horizon_days": 1e5}, field: "parameters.benefit_horizon_days"},
+		{name: "Negative Depeg Threshold", taskType: TaskTypeDepegCheck, parameters: map[string]interface{}{"token_address": "0xlst", "threshold_bps": -10.0}, field: "parameters.threshold_bps"},
+		{name: "Zero Depeg Threshold", taskType: TaskTypeDepegCheck, parameters: map[string]interface{}{"token_address": "0xlst", "threshold_bps": 0.0}, field: "parameters.threshold_bps"},
+		{name: "Depeg Threshold Over Range", taskType: TaskTypeDepegCheck, parameters: map[string]interface{}{"token_address": "0xlst", "threshold_bps": 20000.0}, field: "parameters.threshold_bps"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := &TaskPayload{Type: tc.taskType, Parameters: tc.parameters}
+			defaults := DefaultHandlerDefaults()
+
+			var err error
+			switch tc.taskType {
+			case TaskTypeYieldMonitoring:
+				_, err = parseYieldMonitoringParams(payload, defaults)
+			case TaskTypePositionAdjustment:
+				_, err = parsePositionAdjustmentParams(payload, defaults)
+			case TaskTypeRebalancing:
+				_, err = parseRebalancingParams(payload, defaults)
+			case TaskTypeDepegCheck:
+				_, err = parseDepegCheckParams(payload, defaults)
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("Expected FieldError, got %v", err)
+			}
+			if fieldErr.Field != tc.field {
+				t.Errorf("Expected error on %s, got %s", tc.field, fieldErr.Field)
+			}
+		})
+	}
+}
+
+func Test_ValidateTaskRejectsAbsurdSlippage(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type:       TaskTypePositionAdjustment,
+		Position:   testPosition(),
+		Parameters: map[string]interface{}{"max_slippage": 5.0},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+
+	err = performer.ValidateTask(&performerV1.TaskRequest{TaskId: []byte("absurd-slippage"), Payload: payloadBytes})
+	if err == nil {
+		t.Fatalf("Expected max_slippage 5.0 to be rejected")
+	}
+	if !strings.Contains(err.Error(), "parameters.max_slippage") {
+		t.Errorf("Expected error to name parameters.max_slippage, got %v", err)
+	}
+}
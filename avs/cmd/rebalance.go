@@ -0,0 +1,288 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// rebalanceEpsilon absorbs floating point noise when comparing weights and amounts
+const rebalanceEpsilon = 1e-12
+
+// Gas used by a rebalancing transaction. LST pools are paired with WETH, so a swap
+// touching ETH/WETH is a single hop while an LST-to-LST swap routes through WETH.
+const (
+	RebalanceBaseGas uint64 = 21000
+	DirectSwapGas    uint64 = 120000
+	RoutedSwapGas    uint64 = 200000
+)
+
+const (
+	daysPerYear = 365.0
+	weiPerGwei  = 1e9
+)
+
+// Reason codes reported with a rebalancing plan
+const (
+	reasonWithinBands    = "within_tolerance"
+	reasonDriftExceeds   = "drift_exceeds_tolerance"
+	reasonOnlyDustTrades = "below_min_trade_size"
+	reasonGasExceeds     = "gas_cost_exceeds_benefit"
+)
+
+// DefaultTargetAllocation is used when a rebalancing task carries no target weights
+var DefaultTargetAllocation = map[string]float64{
+	"stETH": 0.4,
+	"rETH":  0.35,
+	"cbETH": 0.25,
+}
+
+// RebalanceTrade swaps Amount (denominated in ETH value) of the Sell token into the Buy token
+type RebalanceTrade struct {
+	Sell   string  `json:"sell"`
+	Buy    string  `json:"buy"`
+	Amount float64 `json:"amount"`
+}
+
+// RebalancePlan is the outcome of planRebalance. GasCost and ExpectedBenefit are
+// denominated in ETH; ExpectedBenefit is only computed when token yields are known.
+type RebalancePlan struct {
+	RebalanceRequired bool
+	ReasonCode        string
+	CurrentWeights    map[string]float64
+	MaxDeviation      float64
+	Trades            []RebalanceTrade
+	GasUnits          uint64
+	GasCost           float64
+	ExpectedBenefit   float64
+}
+
+// planRebalance computes the trades needed to bring every token back within its
+// drift tolerance of the target weight while minimizing turnover.
+//
+// Tokens outside their band are only moved back to the nearest band edge, not to
+// the target. Any imbalance between those sells and buys is then absorbed by the
+// tokens furthest from target, first up to the target and then up to their band
+// edge. Trades smaller than MinTradeSize are dropped, and rebalancing is only
+// required when at least one trade remains and, if token yields are known, the
+// yield gained over the benefit horizon exceeds the gas cost of the trades.
+func planRebalance(params *RebalancingParams) *RebalancePlan {
+	targets := params.TargetAllocation
+	if len(targets) == 0 {
+		targets = DefaultTargetAllocation
+	}
+
+	tokens := sortedTokens(params.CurrentAllocation, targets)
+
+	// Sum in token order: float addition is not associative, and map order is random
+	total := 0.0
+	for _, token := range tokens {
+		total += params.CurrentAllocation[token]
+	}
+
+	plan := &RebalancePlan{
+		ReasonCode:     reasonWithinBands,
+		CurrentWeights: make(map[string]float64, len(tokens)),
+	}
+	if total <= 0 {
+		return plan
+	}
+
+	tolerance := func(token string) float64 {
+		if drift, ok := params.MaxDrift[token]; ok {
+			return drift
+		}
+		return params.RebalanceThreshold
+	}
+
+	// Move every out-of-band token to its nearest band edge
+	weights := make(map[string]float64, len(tokens))
+	adjust := make(map[string]float64, len(tokens))
+	outOfBand := false
+	for _, token := range tokens {
+		weight := params.CurrentAllocation[token] / total
+		weights[token] = weight
+		plan.CurrentWeights[token] = weight
+
+		drift := weight - targets[token]
+		plan.MaxDeviation = math.Max(plan.MaxDeviation, math.Abs(drift))
+
+		tol := tolerance(token)
+		switch {
+		case drift > tol+rebalanceEpsilon:
+			adjust[token] = -(drift - tol) * total
+			outOfBand = true
+		case drift < -tol-rebalanceEpsilon:
+			adjust[token] = (-drift - tol) * total
+			outOfBand = true
+		}
+	}
+	if !outOfBand {
+		return plan
+	}
+
+	// Sells and buys must net to zero; absorb the difference in the remaining tokens
+	net := 0.0
+	for _, token := range tokens {
+		net += adjust[token]
+	}
+	afterWeight := func(token string) float64 {
+		return weights[token] + adjust[token]/total
+	}
+	if net > rebalanceEpsilon {
+		absorb(tokens, adjust, net, total, func(token string) float64 {
+			return afterWeight(token) - targets[token]
+		}, func(token string) float64 {
+			return afterWeight(token) - (targets[token] - tolerance(token))
+		}, -1)
+	} else if net < -rebalanceEpsilon {
+		absorb(tokens, adjust, -net, total, func(token string) float64 {
+			return targets[token] - afterWeight(token)
+		}, func(token string) float64 {
+			return targets[token] + tolerance(token) - afterWeight(token)
+		}, 1)
+	}
+
+	for _, trade := range pairTrades(tokens, adjust) {
+		if trade.Amount < params.MinTradeSize || trade.Amount <= rebalanceEpsilon {
+			continue
+		}
+		plan.Trades = append(plan.Trades, trade)
+	}
+	if len(plan.Trades) == 0 {
+		plan.ReasonCode = reasonOnlyDustTrades
+		return plan
+	}
+
+	plan.GasUnits = estimateRebalanceGas(plan.Trades)
+	plan.GasCost = float64(plan.GasUnits) * params.GasPriceGwei / weiPerGwei
+	plan.RebalanceRequired = true
+	plan.ReasonCode = reasonDriftExceeds
+
+	if len(params.TokenYields) > 0 {
+		plan.ExpectedBenefit = expectedRebalanceBenefit(plan.Trades, params.TokenYields, params.BenefitHorizonDays)
+		if plan.ExpectedBenefit <= plan.GasCost {
+			plan.RebalanceRequired = false
+			plan.ReasonCode = reasonGasExceeds
+		}
+	}
+
+	return plan
+}
+
+// estimateRebalanceGas returns the gas units needed to execute the trades in one transaction
+func estimateRebalanceGas(trades []RebalanceTrade) uint64 {
+	gas := RebalanceBaseGas
+	for _, trade := range trades {
+		if isNativeToken(trade.Sell) || isNativeToken(trade.Buy) {
+			gas += DirectSwapGas
+		} else {
+			gas += RoutedSwapGas
+		}
+	}
+	return gas
+}
+
+// expectedRebalanceBenefit returns the yield (in ETH) the trades add to the portfolio
+// over the horizon, given each token's annual yield
+func expectedRebalanceBenefit(trades []RebalanceTrade, yields map[string]float64, horizonDays float64) float64 {
+	benefit := 0.0
+	for _, trade := range trades {
+		benefit += trade.Amount * (yields[trade.Buy] - yields[trade.Sell])
+	}
+	return benefit * horizonDays / daysPerYear
+}
+
+func isNativeToken(token string) bool {
+	return token == "ETH" || token == "WETH"
+}
+
+// absorb spreads amount across tokens in direction (+1 buy, -1 sell), taking from
+// the tokens with the largest toTarget gap first. Each token takes at most its
+// toTarget gap, then at most its toEdge gap if the targets alone are not enough.
+func absorb(tokens []string, adjust map[string]float64, amount, total float64, toTarget, toEdge func(string) float64, direction float64) {
+	candidates := append([]string(nil), tokens...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return toTarget(candidates[i]) > toTarget(candidates[j])
+	})
+
+	for _, gap := range []func(string) float64{toTarget, toEdge} {
+		for _, token := range candidates {
+			if amount <= rebalanceEpsilon {
+				return
+			}
+			room := gap(token) * total
+			if room <= rebalanceEpsilon {
+				continue
+			}
+			take := math.Min(room, amount)
+			adjust[token] += direction * take
+			amount -= take
+		}
+	}
+}
+
+// pairTrades matches sells with buys, largest first, into the fewest swaps
+func pairTrades(tokens []string, adjust map[string]float64) []RebalanceTrade {
+	type leg struct {
+		token  string
+		amount float64
+	}
+
+	var sells, buys []leg
+	for _, token := range tokens {
+		switch amount := adjust[token]; {
+		case amount < -rebalanceEpsilon:
+			sells = append(sells, leg{token, -amount})
+		case amount > rebalanceEpsilon:
+			buys = append(buys, leg{token, amount})
+		}
+	}
+	sort.SliceStable(sells, func(i, j int) bool { return sells[i].amount > sells[j].amount })
+	sort.SliceStable(buys, func(i, j int) bool { return buys[i].amount > buys[j].amount })
+
+	var trades []RebalanceTrade
+	for i, j := 0, 0; i < len(sells) && j < len(buys); {
+		amount := math.Min(sells[i].amount, buys[j].amount)
+		trades = append(trades, RebalanceTrade{
+			Sell:   sells[i].token,
+			Buy:    buys[j].token,
+			Amount: roundAmount(amount),
+		})
+		sells[i].amount -= amount
+		buys[j].amount -= amount
+		if sells[i].amount <= rebalanceEpsilon {
+			i++
+		}
+		if buys[j].amount <= rebalanceEpsilon {
+			j++
+		}
+	}
+	return trades
+}
+
+// roundAmount rounds an ETH-denominated amount to gwei precision
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*1e9) / 1e9
+}
+
+// sortedTokens returns the union of the keys of the given maps in sorted order
+func sortedTokens(allocations ...map[string]float64) []string {
+	seen := make(map[string]struct{})
+	var tokens []string
+	for _, allocation := range allocations {
+		for token := range allocation {
+			if _, ok := seen[token]; !ok {
+				seen[token] = struct{}{}
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// formatEth renders an ETH amount at gwei precision
+func formatEth(amount float64) string {
+	return strconv.FormatFloat(roundAmount(amount), 'f', -1, 64)
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+func Test_PlanRebalanceDriftedPortfolio(t *testing.T) {
+	params := &RebalancingParams{
+		RebalanceThreshold: 0.02,
+		CurrentAllocation: map[string]float64{
+			"stETH": 60,
+			"rETH":  25,
+			"cbETH": 15,
+		},
+	}
+
+	plan := planRebalance(params)
+
+	if !plan.RebalanceRequired {
+		t.Fatalf("Expected rebalancing to be required")
+	}
+	if math.Abs(plan.MaxDeviation-0.2) > 1e-9 {
+		t.Errorf("Expected max deviation 0.2, got %v", plan.MaxDeviation)
+	}
+
+	// stETH is cut to its upper band edge (42%), rETH raised to its lower band
+	// edge (33%), and the remaining 2 ETH of sells brings cbETH up to target.
+	expected := []RebalanceTrade{
+		{Sell: "stETH", Buy: "cbETH", Amount: 10},
+		{Sell: "stETH", Buy: "rETH", Amount: 8},
+	}
+	if !reflect.DeepEqual(plan.Trades, expected) {
+		t.Errorf("Expected trades %+v, got %+v", expected, plan.Trades)
+	}
+
+	assertWithinTolerance(t, params, plan)
+}
+
+func Test_PlanRebalanceBalancedPortfolio(t *testing.T) {
+	params := &RebalancingParams{
+		RebalanceThreshold: 0.02,
+		CurrentAllocation: map[string]float64{
+			"stETH": 41,
+			"rETH":  34,
+			"cbETH": 25,
+		},
+	}
+
+	plan := planRebalance(params)
+
+	if plan.RebalanceRequired {
+		t.Errorf("Expected no rebalancing for a portfolio within tolerance")
+	}
+	if len(plan.Trades) != 0 {
+		t.Errorf("Expected no trades, got %+v", plan.Trades)
+	}
+}
+
+func Test_PlanRebalanceRespectsConstraints(t *testing.T) {
+	params := &RebalancingParams{
+		RebalanceThreshold: 0.02,
+		CurrentAllocation: map[string]float64{
+			"stETH": 53,
+			"rETH":  47,
+		},
+		TargetAllocation: map[string]float64{
+			"stETH": 0.5,
+			"rETH":  0.5,
+		},
+	}
+
+	// A 3% drift exceeds the default threshold
+	if plan := planRebalance(params); !plan.RebalanceRequired || len(plan.Trades) != 1 {
+		t.Fatalf("Expected a single trade, got %+v", plan.Trades)
+	}
+
+	// A looser per-token drift limit keeps the portfolio in tolerance
+	params.MaxDrift = map[string]float64{"stETH": 0.05, "rETH": 0.05}
+	if plan := planRebalance(params); plan.RebalanceRequired {
+		t.Errorf("Expected max_drift override to suppress rebalancing, got %+v", plan.Trades)
+	}
+
+	// Trades below the minimum trade size are dropped
+	params.MaxDrift = nil
+	params.MinTradeSize = 5
+	if plan := planRebalance(params); plan.RebalanceRequired {
+		t.Errorf("Expected dust trade to be dropped, got %+v", plan.Trades)
+	}
+}
+
+func Test_ParseRebalancingParamsRejectsInvalidAllocations(t *testing.T) {
+	testCases := []struct {
+		name       string
+		parameters map[string]interface{}
+	}{
+		{
+			name:       "Missing Current Allocation",
+			parameters: map[string]interface{}{},
+		},
+		{
+			name:       "Negative Holding",
+			parameters: map[string]interface{}{"current_allocation": map[string]interface{}{"stETH": -1.0}},
+		},
+		{
+			name: "Holdings Overflow The Portfolio Total",
+			parameters: map[string]interface{}{
+				"current_allocation": map[string]interface{}{"a": 1e308, "b": 1e308},
+			},
+		},
+		{
+			name:       "Missing Gas Price",
+			parameters: map[string]interface{}{"current_allocation": map[string]interface{}{"stETH": 1.0}},
+		},
+		{
+			name:       "Holding Above Maximum",
+			parameters: map[string]interface{}{"current_allocation": map[string]interface{}{"stETH": 2e9}},
+		},
+		{
+			name: "Target Weights Do Not Sum To One",
+			parameters: map[string]interface{}{
+				"current_allocation": map[string]interface{}{"stETH": 1.0},
+				"target_allocation":  map[string]interface{}{"stETH": 0.5, "rETH": 0.2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := parseRebalancingParams(&TaskPayload{Parameters: tc.parameters}, DefaultHandlerDefaults()); err == nil {
+				t.Errorf("Expected %s to be rejected", tc.name)
+			}
+		})
+	}
+}
+
+// assertWithinTolerance applies the planned trades and checks every token ends in its band
+func assertWithinTolerance(t *testing.T, params *RebalancingParams, plan *RebalancePlan) {
+	t.Helper()
+
+	holdings := make(map[string]float64)
+	total := 0.0
+	for token, amount := range params.CurrentAllocation {
+		holdings[token] = amount
+		total += amount
+	}
+	for _, trade := range plan.Trades {
+		holdings[trade.Sell] -= trade.Amount
+		holdings[trade.Buy] += trade.Amount
+	}
+
+	targets := params.TargetAllocation
+	if len(targets) == 0 {
+		targets = DefaultTargetAllocation
+	}
+	for token, target := range targets {
+		weight := holdings[token] / total
+		if math.Abs(weight-target) > params.RebalanceThreshold+1e-9 {
+			t.Errorf("Token %s ends at weight %v, outside %v of target %v", token, weight, params.RebalanceThreshold, target)
+		}
+	}
+}
+
+func Test_PlanRebalanceSuppressedWhenGasExceedsBenefit(t *testing.T) {
+	yields := map[string]float64{
+		"stETH": 0.030,
+		"rETH":  0.035,
+		"cbETH": 0.032,
+	}
+
+	// A 1.5 ETH stETH->rETH swap gains ~0.0006 ETH over 30 days but costs ~0.0044 ETH in gas
+	small := &RebalancingParams{
+		RebalanceThreshold: 0.02,
+		GasPriceGwei:       20,
+		BenefitHorizonDays: 30,
+		TokenYields:        yields,
+		CurrentAllocation: map[string]float64{
+			"stETH": 43.5,
+			"rETH":  32,
+			"cbETH": 24.5,
+		},
+	}
+
+	plan := planRebalance(small)
+	if len(plan.Trades) != 1 {
+		t.Fatalf("Expected one candidate trade, got %+v", plan.Trades)
+	}
+	if plan.GasUnits != RebalanceBaseGas+RoutedSwapGas {
+		t.Errorf("Expected %d gas units, got %d", RebalanceBaseGas+RoutedSwapGas, plan.GasUnits)
+	}
+	if plan.RebalanceRequired {
+		t.Errorf("Expected small drift to be suppressed: benefit %v, gas %v", plan.ExpectedBenefit, plan.GasCost)
+	}
+	if plan.ReasonCode != reasonGasExceeds {
+		t.Errorf("Expected reason %s, got %s", reasonGasExceeds, plan.ReasonCode)
+	}
+
+	// The same drift shape on a 1000 ETH portfolio is worth the gas
+	large := &RebalancingParams{
+		RebalanceThreshold: 0.02,
+		GasPriceGwei:       20,
+		BenefitHorizonDays: 30,
+		TokenYields:        yields,
+		CurrentAllocation: map[string]float64{
+			"stETH": 600,
+			"rETH":  250,
+			"cbETH": 150,
+		},
+	}
+
+	plan = planRebalance(large)
+	if !plan.RebalanceRequired {
+		t.Errorf("Expected large drift to be worth the gas: benefit %v, gas %v", plan.ExpectedBenefit, plan.GasCost)
+	}
+	if plan.ExpectedBenefit <= plan.GasCost {
+		t.Errorf("Expected benefit %v to exceed gas cost %v", plan.ExpectedBenefit, plan.GasCost)
+	}
+}
+
+func Test_EstimateRebalanceGasByTradeType(t *testing.T) {
+	trades := []RebalanceTrade{
+		{Sell: "stETH", Buy: "WETH", Amount: 1},
+		{Sell: "stETH", Buy: "rETH", Amount: 1},
+	}
+
+	expected := RebalanceBaseGas + DirectSwapGas + RoutedSwapGas
+	if gas := estimateRebalanceGas(trades); gas != expected {
+		t.Errorf("Expected %d gas units, got %d", expected, gas)
+	}
+}
+
+func Test_RebalancingResultIsIndependentOfMapOrder(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	// Sums of these amounts differ in the last bit depending on addition order
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type: TaskTypeRebalancing,
+		Parameters: map[string]interface{}{
+			"current_allocation": map[string]interface{}{"stETH": 0.1, "rETH": 0.2, "cbETH": 0.3, "ETH": 0.7},
+			"gas_price_gwei":     20.0,
+		},
+		Position:  testPosition(),
+		Timestamp: 1700000000,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+
+	taskRequest := &performerV1.TaskRequest{
+		TaskId:  []byte("rebalance-map-order"),
+		Payload: payloadBytes,
+	}
+
+	var expected []byte
+	for i := 0; i < 300; i++ {
+		resp, err := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources()).HandleTask(taskRequest)
+		if err != nil {
+			t.Fatalf("HandleTask failed: %v", err)
+		}
+		if expected == nil {
+			expected = resp.Result
+			continue
+		}
+		if string(resp.Result) != string(expected) {
+			t.Fatalf("Run %d produced different bytes\nfirst: %s\nrun:   %s", i, expected, resp.Result)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Uniswap V4 tick bounds (TickMath.MIN_TICK / TickMath.MAX_TICK)
+const (
+	MinTick int32 = -887272
+	MaxTick int32 = 887272
+)
+
+// clampTick limits tick to the valid range [MinTick, MaxTick]
+func clampTick(tick int32) int32 {
+	if tick < MinTick {
+		return MinTick
+	}
+	if tick > MaxTick {
+		return MaxTick
+	}
+	return tick
+}
+
+// ParamKind is the JSON type expected for a task parameter
+type ParamKind string
+
+const (
+	ParamKindString ParamKind = "string"
+	ParamKindNumber ParamKind = "number"
+	ParamKindBool   ParamKind = "boolean"
+	ParamKindObject ParamKind = "object"
+	ParamKindArray  ParamKind = "array"
+)
+
+// ParamSchema describes a single entry of TaskPayload.Parameters
+type ParamSchema struct {
+	Kind     ParamKind
+	Required bool
+}
+
+// TaskSchema describes the expected shape of a TaskPayload for one task type.
+// Parameters not listed in the schema are accepted and ignored.
+type TaskSchema struct {
+	Parameters       map[string]ParamSchema
+	RequiresLSTData  bool
+	RequiresPosition bool
+}
+
+// taskSchemas holds the payload schema for every supported task type
+var taskSchemas = map[TaskType]TaskSchema{
+	TaskTypeYieldMonitoring: {
+		Parameters: map[string]ParamSchema{
+			"pool_address":    {Kind: ParamKindString, Required: true},
+			"threshold":       {Kind: ParamKindNumber},
+			"baseline_window": {Kind: ParamKindNumber},
+		},
+	},
+	TaskTypePositionAdjustment: {
+		Parameters: map[string]ParamSchema{
+			"target_yield": {Kind: ParamKindNumber},
+			"max_slippage": {Kind: ParamKindNumber},
+		},
+		RequiresPosition: true,
+	},
+	TaskTypeRiskAssessment: {
+		Parameters:      map[string]ParamSchema{},
+		RequiresLSTData: true,
+	},
+	TaskTypeRebalancing: {
+		Parameters: map[string]ParamSchema{
+			"rebalance_threshold":  {Kind: ParamKindNumber},
+			"current_allocation":   {Kind: ParamKindObject, Required: true},
+			"target_allocation":    {Kind: ParamKindObject},
+			"min_trade_size":       {Kind: ParamKindNumber},
+			"max_drift":            {Kind: ParamKindObject},
+			"gas_price_gwei":       {Kind: ParamKindNumber, Required: true},
+			"benefit_horizon_days": {Kind: ParamKindNumber},
+			"token_yields":         {Kind: ParamKindObject},
+		},
+		RequiresPosition: true,
+	},
+	TaskTypeLSTValidation: {
+		Parameters: map[string]ParamSchema{
+			"token_address": {Kind: ParamKindString, Required: true},
+		},
+	},
+	TaskTypeDepegCheck: {
+		Parameters: map[string]ParamSchema{
+			"token_address": {Kind: ParamKindString, Required: true},
+			"threshold_bps": {Kind: ParamKindNumber},
+		},
+	},
+	TaskTypeBatch: {
+		Parameters: map[string]ParamSchema{
+			"continue_on_error": {Kind: ParamKindBool},
+		},
+	},
+}
+
+// FieldError reports a schema violation for a single payload field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// validatePayloadSchema checks a parsed payload against the schema registered for
+// its task type and returns the first field-level violation found.
+func validatePayloadSchema(payload *TaskPayload) error {
+	schema, ok := taskSchemas[payload.Type]
+	if !ok {
+		return &FieldError{Field: "type", Message: fmt.Sprintf("unsupported task type %q", payload.Type)}
+	}
+
+	// Check parameters in a stable order so errors are reproducible
+	names := make([]string, 0, len(schema.Parameters))
+	for name := range schema.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		param := schema.Parameters[name]
+		value, present := payload.Parameters[name]
+		if !present || value == nil {
+			if param.Required {
+				return &FieldError{Field: "parameters." + name, Message: "required field is missing"}
+			}
+			continue
+		}
+		if kind := jsonKind(value); kind != param.Kind {
+			return &FieldError{
+				Field:   "parameters." + name,
+				Message: fmt.Sprintf("expected %s, got %s", param.Kind, kind),
+			}
+		}
+	}
+
+	if schema.RequiresLSTData && len(payload.LSTData) == 0 {
+		return &FieldError{Field: "lst_data", Message: "at least one entry is required"}
+	}
+	for i, lst := range payload.LSTData {
+		if lst.TokenAddress == "" {
+			return &FieldError{Field: fmt.Sprintf("lst_data[%d].token_address", i), Message: "required field is missing"}
+		}
+		if lst.CurrentYield != nil && lst.CurrentYield.Sign() < 0 {
+			return &FieldError{Field: fmt.Sprintf("lst_data[%d].current_yield", i), Message: "must not be negative"}
+		}
+	}
+
+	if schema.RequiresPosition && payload.Position == nil {
+		return &FieldError{Field: "position", Message: "required field is missing"}
+	}
+	if payload.Position != nil {
+		if err := validatePositionSchema(payload.Position); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validatePositionSchema checks tick bounds and amounts of an LP position
+func validatePositionSchema(position *PositionData) error {
+	if position.PoolId == "" {
+		return &FieldError{Field: "position.pool_id", Message: "required field is missing"}
+	}
+	if position.LowerTick < MinTick || position.LowerTick > MaxTick {
+		return &FieldError{
+			Field:   "position.lower_tick",
+			Message: fmt.Sprintf("must be within [%d, %d], got %d", MinTick, MaxTick, position.LowerTick),
+		}
+	}
+	if position.UpperTick < MinTick || position.UpperTick > MaxTick {
+		return &FieldError{
+			Field:   "position.upper_tick",
+			Message: fmt.Sprintf("must be within [%d, %d], got %d", MinTick, MaxTick, position.UpperTick),
+		}
+	}
+	if position.LowerTick >= position.UpperTick {
+		return &FieldError{
+			Field:   "position.lower_tick",
+			Message: fmt.Sprintf("must be less than upper_tick (%d >= %d)", position.LowerTick, position.UpperTick),
+		}
+	}
+	if position.Liquidity != nil && position.Liquidity.Sign() < 0 {
+		return &FieldError{Field: "position.liquidity", Message: "must not be negative"}
+	}
+	return nil
+}
+
+// jsonKind returns the JSON type of a value decoded by encoding/json into interface{}
+func jsonKind(value interface{}) ParamKind {
+	switch value.(type) {
+	case string:
+		return ParamKindString
+	case float64:
+		return ParamKindNumber
+	case bool:
+		return ParamKindBool
+	case map[string]interface{}:
+		return ParamKindObject
+	case []interface{}:
+		return ParamKindArray
+	default:
+		return ParamKind(fmt.Sprintf("%T", value))
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+func Test_ValidateTaskRejectsSchemaViolations(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+	testCases := []struct {
+		name      string
+		payload   string
+		wantField string
+	}{
+		{
+			name:      "String Threshold",
+			payload:   `{"type":"yield_monitoring","parameters":{"pool_address":"0xpool","threshold":"0.01"}}`,
+			wantField: "parameters.threshold",
+		},
+		{
+			name:      "Numeric Pool Address",
+			payload:   `{"type":"yield_monitoring","parameters":{"pool_address":1234}}`,
+			wantField: "parameters.pool_address",
+		},
+		{
+			name:      "Missing Token Address",
+			payload:   `{"type":"lst_validation","parameters":{}}`,
+			wantField: "parameters.token_address",
+		},
+		{
+			name:      "String Max Slippage",
+			payload:   `{"type":"position_adjustment","parameters":{"max_slippage":"high"},"position":{"pool_id":"0xpool","lower_tick":-60,"upper_tick":60}}`,
+			wantField: "parameters.max_slippage",
+		},
+		{
+			name:      "Lower Tick Below Minimum",
+			payload:   `{"type":"position_adjustment","parameters":{},"position":{"pool_id":"0xpool","lower_tick":-900000,"upper_tick":60}}`,
+			wantField: "position.lower_tick",
+		},
+		{
+			name:      "Inverted Tick Range",
+			payload:   `{"type":"position_adjustment","parameters":{},"position":{"pool_id":"0xpool","lower_tick":120,"upper_tick":60}}`,
+			wantField: "position.lower_tick",
+		},
+		{
+			name:      "Negative Current Yield",
+			payload:   `{"type":"risk_assessment","parameters":{},"lst_data":[{"token_address":"0xlst","current_yield":-5}]}`,
+			wantField: "lst_data[0].current_yield",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRequest := &performerV1.TaskRequest{
+				TaskId:  []byte("schema-test"),
+				Payload: []byte(tc.payload),
+			}
+
+			// The payload must be parseable so the schema is what rejects it
+			if _, err := parseTaskPayload(taskRequest); err != nil {
+				t.Fatalf("Payload unexpectedly failed to parse: %v", err)
+			}
+
+			err := performer.ValidateTask(taskRequest)
+			if err == nil {
+				t.Fatalf("ValidateTask accepted invalid payload for %s", tc.name)
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("Expected FieldError, got %v", err)
+			}
+			if fieldErr.Field != tc.wantField {
+				t.Errorf("Expected error on field %s, got %s (%v)", tc.wantField, fieldErr.Field, err)
+			}
+		})
+	}
+}
+
+func Test_ValidatePayloadSchemaAcceptsNegativeTicks(t *testing.T) {
+	payload := &TaskPayload{
+		Type:       TaskTypePositionAdjustment,
+		Parameters: map[string]interface{}{"max_slippage": 0.01},
+		Position: &PositionData{
+			PoolId:    "0xpool",
+			LowerTick: -1200,
+			UpperTick: -600,
+		},
+	}
+
+	if err := validatePayloadSchema(payload); err != nil {
+		t.Errorf("Expected valid negative tick range to pass, got %v", err)
+	}
+}
+
+func Test_ValidatePayloadSchemaIgnoresUnknownParameters(t *testing.T) {
+	payload := &TaskPayload{
+		Type: TaskTypeLSTValidation,
+		Parameters: map[string]interface{}{
+			"token_address": "0xlst",
+			"note":          42.0,
+		},
+	}
+
+	if err := validatePayloadSchema(payload); err != nil {
+		t.Errorf("Expected unknown parameters to be ignored, got %v", err)
+	}
+
+	payload.Parameters["token_address"] = true
+	err := validatePayloadSchema(payload)
+	if err == nil || !strings.Contains(err.Error(), "expected string, got boolean") {
+		t.Errorf("Expected type mismatch error, got %v", err)
+	}
+}
+
+func Test_PositionAdjustmentClampsTicksToBounds(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), testDataSources())
+
+	testCases := []struct {
+		name      string
+		lowerTick int32
+		upperTick int32
+		wantLower int32
+		wantUpper int32
+	}{
+		{name: "At Bounds", lowerTick: MinTick, upperTick: MaxTick, wantLower: MinTick, wantUpper: MaxTick},
+		{name: "Near Bounds", lowerTick: MinTick + 50, upperTick: MaxTick - 50, wantLower: MinTick, wantUpper: MaxTick},
+		{name: "Inside Bounds", lowerTick: -600, upperTick: 600, wantLower: -700, wantUpper: 700},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			position := testPosition()
+			position.LowerTick = tc.lowerTick
+			position.UpperTick = tc.upperTick
+
+			payloadBytes, err := json.Marshal(TaskPayload{
+				Type:       TaskTypePositionAdjustment,
+				Parameters: map[string]interface{}{},
+				Position:   position,
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal payload: %v", err)
+			}
+
+			taskRequest := &performerV1.TaskRequest{TaskId: []byte("tick-bounds"), Payload: payloadBytes}
+			if err := performer.ValidateTask(taskRequest); err != nil {
+				t.Fatalf("ValidateTask failed: %v", err)
+			}
+
+			resp, err := performer.HandleTask(taskRequest)
+			if err != nil {
+				t.Fatalf("HandleTask failed: %v", err)
+			}
+
+			var result YieldAdjustmentResult
+			if err := json.Unmarshal(resp.Result, &result); err != nil {
+				t.Fatalf("Failed to decode result: %v", err)
+			}
+			if result.NewLowerTick != tc.wantLower || result.NewUpperTick != tc.wantUpper {
+				t.Errorf("Expected ticks [%d, %d], got [%d, %d]", tc.wantLower, tc.wantUpper, result.NewLowerTick, result.NewUpperTick)
+			}
+		})
+	}
+}
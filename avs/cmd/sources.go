@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// dataSourceTimeout bounds each request to an external per-token data source
+const dataSourceTimeout = 5 * time.Second
+
+// errSourceUnavailable marks failures to load data from an external data source
+var errSourceUnavailable = errors.New("data source unavailable")
+
+// tokenEndpoints maps lowercased LST token addresses to the URL serving their data
+type tokenEndpoints map[string]string
+
+func newTokenEndpoints(endpoints map[string]string) tokenEndpoints {
+	normalized := make(tokenEndpoints, len(endpoints))
+	for token, url := range endpoints {
+		normalized[strings.ToLower(token)] = url
+	}
+	return normalized
+}
+
+func (e tokenEndpoints) has(tokenAddress string) bool {
+	_, ok := e[strings.ToLower(tokenAddress)]
+	return ok
+}
+
+// tokenScopedSource is implemented by data sources that serve a fixed set of tokens
+type tokenScopedSource interface {
+	SupportsToken(tokenAddress string) bool
+}
+
+// supportsToken reports whether source can serve data for tokenAddress. Sources
+// that do not declare their tokens are assumed to serve all of them.
+func supportsToken(source interface{}, tokenAddress string) bool {
+	if source == nil {
+		return false
+	}
+	if scoped, ok := source.(tokenScopedSource); ok {
+		return scoped.SupportsToken(tokenAddress)
+	}
+	return true
+}
+
+// fetchTokenJSON GETs the endpoint configured for tokenAddress and decodes the JSON
+// response into out. kind names the data in error messages.
+func fetchTokenJSON(ctx context.Context, client *http.Client, endpoints tokenEndpoints, tokenAddress, kind string, out interface{}) error {
+	url, ok := endpoints[strings.ToLower(tokenAddress)]
+	if !ok {
+		return fmt.Errorf("no %s source configured for token %s", kind, tokenAddress)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", kind, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s for %s: %w", kind, tokenAddress, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s source for %s returned status %d", kind, tokenAddress, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s for %s: %w", kind, tokenAddress, err)
+	}
+	return nil
+}
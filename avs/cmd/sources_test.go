@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_FetchTokenJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/quote":
+			w.Write([]byte(`{"market_price": 0.99, "redemption_rate": 1.0}`))
+		case "/garbled":
+			w.Write([]byte(`{"market_price":`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	endpoints := newTokenEndpoints(map[string]string{
+		"0xQUOTE":   server.URL + "/quote",
+		"0xGARBLED": server.URL + "/garbled",
+		"0xDOWN":    server.URL + "/down",
+	})
+
+	var quote PegQuote
+	if err := fetchTokenJSON(context.Background(), server.Client(), endpoints, "0xquote", "price", &quote); err != nil {
+		t.Fatalf("Failed to fetch quote: %v", err)
+	}
+	if quote.MarketPrice != 0.99 || quote.RedemptionRate != 1.0 {
+		t.Errorf("Unexpected quote: %+v", quote)
+	}
+
+	testCases := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{name: "Unconfigured Token", token: "0xunknown", wantErr: "no price source configured"},
+		{name: "Error Status", token: "0xdown", wantErr: "returned status 503"},
+		{name: "Malformed Body", token: "0xgarbled", wantErr: "failed to decode price"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out PegQuote
+			err := fetchTokenJSON(context.Background(), server.Client(), endpoints, tc.token, "price", &out)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Default validation thresholds applied to validator-set data
+const (
+	DefaultMinValidatorCount = 100
+	DefaultMinHealthScore    = 80.0
+)
+
+// SlashingEvent is a slashing of one of an LST protocol's validators
+type SlashingEvent struct {
+	ValidatorIndex uint64 `json:"validator_index"`
+	Epoch          uint64 `json:"epoch"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// ValidatorStats describes the validator set backing an LST. HealthScore is the
+// attestation effectiveness of the set in the range [0, 100]. SlashingEvents
+// holds the slashings the source considers recent. BlockNumber is the block the
+// data was observed at, if the source reports it.
+type ValidatorStats struct {
+	ValidatorCount   uint64          `json:"validator_count"`
+	ActiveValidators uint64          `json:"active_validators"`
+	HealthScore      float64         `json:"health_score"`
+	SlashingEvents   []SlashingEvent `json:"slashing_events"`
+	BlockNumber      uint64          `json:"block_number,omitempty"`
+}
+
+// ValidatorDataSource provides validator-set data for an LST token
+type ValidatorDataSource interface {
+	ValidatorStats(ctx context.Context, tokenAddress string) (*ValidatorStats, error)
+}
+
+// ValidatorDataSourceFunc adapts a function to the ValidatorDataSource interface
+type ValidatorDataSourceFunc func(ctx context.Context, tokenAddress string) (*ValidatorStats, error)
+
+func (f ValidatorDataSourceFunc) ValidatorStats(ctx context.Context, tokenAddress string) (*ValidatorStats, error) {
+	return f(ctx, tokenAddress)
+}
+
+// HTTPValidatorDataSource fetches ValidatorStats as JSON from an endpoint configured
+// per token, such as an LST protocol API or a beacon-chain indexer.
+type HTTPValidatorDataSource struct {
+	endpoints tokenEndpoints
+	client    *http.Client
+}
+
+func NewHTTPValidatorDataSource(endpoints map[string]string) *HTTPValidatorDataSource {
+	return &HTTPValidatorDataSource{
+		endpoints: newTokenEndpoints(endpoints),
+		client:    &http.Client{Timeout: dataSourceTimeout},
+	}
+}
+
+// SupportsToken reports whether an endpoint is configured for tokenAddress
+func (s *HTTPValidatorDataSource) SupportsToken(tokenAddress string) bool {
+	return s.endpoints.has(tokenAddress)
+}
+
+func (s *HTTPValidatorDataSource) ValidatorStats(ctx context.Context, tokenAddress string) (*ValidatorStats, error) {
+	var stats ValidatorStats
+	if err := fetchTokenJSON(ctx, s.client, s.endpoints, tokenAddress, "validator data", &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// LSTValidationResult represents the validation verdict for an LST
+type LSTValidationResult struct {
+	TokenAddress      string         `json:"token_address"`
+	IsValid           bool           `json:"is_valid"`
+	ValidatorCount    uint64         `json:"validator_count"`
+	ActiveValidators  uint64         `json:"active_validators"`
+	HealthScore       float64        `json:"health_score"`
+	LastSlashingEvent *SlashingEvent `json:"last_slashing_event"`
+	Reasons           []string       `json:"reasons,omitempty"`
+	BlockNumber       uint64         `json:"block_number,omitempty"`
+	Timestamp         int64          `json:"timestamp,omitempty"`
+}
+
+// evaluateValidatorStats computes the validation verdict for an LST. A token is
+// invalid if any recent slashing is reported, its validator set is too small, or
+// its health score is below the minimum.
+func evaluateValidatorStats(tokenAddress string, stats *ValidatorStats, minValidatorCount uint64, minHealthScore float64) *LSTValidationResult {
+	result := &LSTValidationResult{
+		TokenAddress:     tokenAddress,
+		IsValid:          true,
+		ValidatorCount:   stats.ValidatorCount,
+		ActiveValidators: stats.ActiveValidators,
+		HealthScore:      stats.HealthScore,
+		BlockNumber:      stats.BlockNumber,
+	}
+
+	for i := range stats.SlashingEvents {
+		event := stats.SlashingEvents[i]
+		if result.LastSlashingEvent == nil || event.Epoch > result.LastSlashingEvent.Epoch {
+			result.LastSlashingEvent = &event
+		}
+	}
+
+	if result.LastSlashingEvent != nil {
+		result.IsValid = false
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%d recent slashing event(s)", len(stats.SlashingEvents)))
+	}
+	if stats.ValidatorCount < minValidatorCount {
+		result.IsValid = false
+		result.Reasons = append(result.Reasons, fmt.Sprintf("validator count %d below minimum %d", stats.ValidatorCount, minValidatorCount))
+	}
+	if stats.HealthScore < minHealthScore {
+		result.IsValid = false
+		result.Reasons = append(result.Reasons, fmt.Sprintf("health score %g below minimum %g", stats.HealthScore, minHealthScore))
+	}
+
+	return result
+}
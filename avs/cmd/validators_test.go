@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"go.uber.org/zap"
+)
+
+// healthyValidatorSource reports a healthy, unslashed validator set for every token
+func healthyValidatorSource() ValidatorDataSource {
+	return ValidatorDataSourceFunc(func(ctx context.Context, tokenAddress string) (*ValidatorStats, error) {
+		return &ValidatorStats{
+			ValidatorCount:   1250,
+			ActiveValidators: 1240,
+			HealthScore:      95,
+		}, nil
+	})
+}
+
+func Test_LSTValidationRejectsSlashedValidatorSet(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	slashed := ValidatorDataSourceFunc(func(ctx context.Context, tokenAddress string) (*ValidatorStats, error) {
+		return &ValidatorStats{
+			ValidatorCount:   1250,
+			ActiveValidators: 1238,
+			HealthScore:      94,
+			SlashingEvents: []SlashingEvent{
+				{ValidatorIndex: 4021, Epoch: 210000, Reason: "attester_slashing"},
+				{ValidatorIndex: 5120, Epoch: 215500, Reason: "proposer_slashing"},
+			},
+		}, nil
+	})
+
+	performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), DataSources{Validators: slashed, Pegs: atPegSource()})
+
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type:       TaskTypeLSTValidation,
+		Parameters: map[string]interface{}{"token_address": "0xlst"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+
+	resp, err := performer.HandleTask(&performerV1.TaskRequest{
+		TaskId:  []byte("slashed-lst"),
+		Payload: payloadBytes,
+	})
+	if err != nil {
+		t.Fatalf("HandleTask failed: %v", err)
+	}
+
+	var result LSTValidationResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+
+	if result.IsValid {
+		t.Errorf("Expected slashed validator set to be invalid")
+	}
+	if result.LastSlashingEvent == nil || result.LastSlashingEvent.Epoch != 215500 {
+		t.Errorf("Expected most recent slashing event at epoch 215500, got %+v", result.LastSlashingEvent)
+	}
+	if result.ValidatorCount != 1250 {
+		t.Errorf("Expected validator count 1250, got %d", result.ValidatorCount)
+	}
+}
+
+func Test_EvaluateValidatorStats(t *testing.T) {
+	testCases := []struct {
+		name      string
+		stats     ValidatorStats
+		wantValid bool
+	}{
+		{
+			name:      "Healthy",
+			stats:     ValidatorStats{ValidatorCount: 1250, HealthScore: 95},
+			wantValid: true,
+		},
+		{
+			name:      "Too Few Validators",
+			stats:     ValidatorStats{ValidatorCount: 12, HealthScore: 95},
+			wantValid: false,
+		},
+		{
+			name:      "Low Health Score",
+			stats:     ValidatorStats{ValidatorCount: 1250, HealthScore: 61.5},
+			wantValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := evaluateValidatorStats("0xlst", &tc.stats, DefaultMinValidatorCount, DefaultMinHealthScore)
+			if result.IsValid != tc.wantValid {
+				t.Errorf("Expected is_valid=%v, got %v (reasons: %v)", tc.wantValid, result.IsValid, result.Reasons)
+			}
+		})
+	}
+}
+
+func Test_HTTPValidatorDataSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ValidatorStats{
+			ValidatorCount: 800,
+			HealthScore:    97.5,
+			SlashingEvents: []SlashingEvent{{ValidatorIndex: 7, Epoch: 100}},
+		})
+	}))
+	defer server.Close()
+
+	source := NewHTTPValidatorDataSource(map[string]string{"0xABC": server.URL})
+
+	stats, err := source.ValidatorStats(context.Background(), "0xabc")
+	if err != nil {
+		t.Fatalf("Failed to fetch validator stats: %v", err)
+	}
+	if stats.ValidatorCount != 800 || len(stats.SlashingEvents) != 1 {
+		t.Errorf("Unexpected validator stats: %+v", stats)
+	}
+
+	if _, err := source.ValidatorStats(context.Background(), "0xunknown"); err == nil {
+		t.Errorf("Expected an error for a token without a configured source")
+	}
+}
+
+func Test_LSTValidationRequiresConfiguredSource(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		sources DataSources
+		token   string
+		wantErr bool
+	}{
+		{name: "No Sources Configured", sources: DataSources{Validators: NewHTTPValidatorDataSource(nil), Pegs: atPegSource()}, token: "0xlst", wantErr: true},
+		{name: "Token Not Configured", sources: DataSources{Validators: NewHTTPValidatorDataSource(map[string]string{"0xother": "http://localhost:9000"}), Pegs: atPegSource()}, token: "0xlst", wantErr: true},
+		{name: "Token Configured", sources: DataSources{Validators: NewHTTPValidatorDataSource(map[string]string{"0xLST": "http://localhost:9000"}), Pegs: atPegSource()}, token: "0xlst", wantErr: false},
+		{name: "Nil Source", sources: DataSources{Pegs: atPegSource()}, token: "0xlst", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), tc.sources)
+
+			payloadBytes, err := json.Marshal(TaskPayload{
+				Type:       TaskTypeLSTValidation,
+				Parameters: map[string]interface{}{"token_address": tc.token},
+			})
+			if err != nil {
+				t.Fatalf("Failed to marshal payload: %v", err)
+			}
+
+			err = performer.ValidateTask(&performerV1.TaskRequest{TaskId: []byte("unconfigured-source"), Payload: payloadBytes})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Expected error=%v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_LSTValidationUsesPayloadSnapshot(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type:       TaskTypeLSTValidation,
+		Parameters: map[string]interface{}{"token_address": "0xlst"},
+		ValidatorStats: &ValidatorStats{
+			ValidatorCount:   1250,
+			ActiveValidators: 1240,
+			HealthScore:      95,
+			BlockNumber:      19000000,
+		},
+		Timestamp: 1700000000,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+	taskRequest := &performerV1.TaskRequest{TaskId: []byte("validator-snapshot"), Payload: payloadBytes}
+
+	// Operators with different (or no) live sources evaluate the same observation
+	sources := []ValidatorDataSource{
+		NewHTTPValidatorDataSource(nil),
+		ValidatorDataSourceFunc(func(ctx context.Context, tokenAddress string) (*ValidatorStats, error) {
+			return &ValidatorStats{ValidatorCount: 3, HealthScore: 10}, nil
+		}),
+	}
+
+	var results [][]byte
+	for _, source := range sources {
+		performer := NewYieldSyncPerformer(logger, DefaultPerformerConfig(), DataSources{Validators: source, Pegs: atPegSource()})
+		if err := performer.ValidateTask(taskRequest); err != nil {
+			t.Fatalf("ValidateTask failed: %v", err)
+		}
+		resp, err := performer.HandleTask(taskRequest)
+		if err != nil {
+			t.Fatalf("HandleTask failed: %v", err)
+		}
+		results = append(results, resp.Result)
+	}
+
+	if string(results[0]) != string(results[1]) {
+		t.Errorf("Expected identical results\nfirst:  %s\nsecond: %s", results[0], results[1])
+	}
+
+	var result LSTValidationResult
+	if err := json.Unmarshal(results[0], &result); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if !result.IsValid || result.BlockNumber != 19000000 {
+		t.Errorf("Expected a valid verdict at block 19000000, got %+v", result)
+	}
+}
+
+func Test_LSTValidationFetchHonorsTaskTimeout(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Errorf("Failed to create logger: %v", err)
+	}
+
+	// The source only returns once the request context is done
+	stalled := ValidatorDataSourceFunc(func(ctx context.Context, tokenAddress string) (*ValidatorStats, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	cfg := DefaultPerformerConfig()
+	cfg.Timeout = 50 * time.Millisecond
+	performer := NewYieldSyncPerformer(logger, cfg, DataSources{Validators: stalled, Pegs: atPegSource()})
+
+	payloadBytes, err := json.Marshal(TaskPayload{
+		Type:       TaskTypeLSTValidation,
+		Parameters: map[string]interface{}{"token_address": "0xlst"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal payload: %v", err)
+	}
+
+	start := time.Now()
+	_, err = performer.HandleTask(&performerV1.TaskRequest{TaskId: []byte("stalled-source"), Payload: payloadBytes})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the fetch to hit the task deadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected HandleTask to return at the task timeout, took %s", elapsed)
+	}
+}